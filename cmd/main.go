@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters"
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports"
@@ -10,6 +11,8 @@ import (
 	"github.com/Pr3c10us/boilerplate/packages/utils"
 	"github.com/redis/go-redis/v9"
 	"log"
+	"os/signal"
+	"syscall"
 )
 
 var (
@@ -22,14 +25,19 @@ func init() {
 
 func main() {
 	newLogger := logger.NewSugarLogger(environmentVariables.ProductionEnvironment)
-	newPGConnection := utils.NewPGConnection(environmentVariables)
-	defer func(db *sql.DB) {
-		_ = db.Close()
-	}(newPGConnection)
-	newRedisConnection := utils.NewRedisClient(environmentVariables)
-	defer func(redis *redis.Client) {
-		_ = redis.Close()
-	}(newRedisConnection)
+	// Memory mode keeps all state in process, so it connects to neither
+	var newPGConnection *sql.DB
+	var newRedisConnection *redis.Client
+	if environmentVariables.AdapterMode != configs.AdapterModeMemory {
+		newPGConnection = utils.NewPGConnection(environmentVariables)
+		defer func(db *sql.DB) {
+			_ = db.Close()
+		}(newPGConnection)
+		newRedisConnection = utils.NewRedisClient(environmentVariables)
+		defer func(redis *redis.Client) {
+			_ = redis.Close()
+		}(newRedisConnection)
+	}
 	newOpenAiClient := utils.NewOpenAIClient(environmentVariables)
 	adapterDependencies := adapters.AdapterDependencies{
 		Logger:               newLogger,
@@ -40,11 +48,43 @@ func main() {
 	}
 	newAdapters := adapters.NewAdapters(adapterDependencies)
 	newServices := services.NewServices(newAdapters)
-	newPort := ports.NewPorts(newServices, newLogger, environmentVariables)
+	newPort, err := ports.NewPorts(newServices, newAdapters.SchedulerStore, newLogger, environmentVariables)
+	if err != nil {
+		log.Fatalf("Failed to create ports: %v", err)
+	}
 	scheduler := newPort.Scheduler
 	if err := scheduler.Initialize(); err != nil {
 		log.Fatalf("Failed to initialize scheduler: %v", err)
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	go newPort.GinServer.Run()
-	scheduler.Run()
+	schedulerDone := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(schedulerDone)
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Printf("Shutting down, waiting up to %v for the server and %v for the scheduler",
+		environmentVariables.ShutdownTimeout, environmentVariables.SchedulerDrainTimeout)
+
+	// The scheduler drains on its own budget, from the same moment as the
+	// server, so a slow publish is not cut short by the server's timeout
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), environmentVariables.SchedulerDrainTimeout)
+	defer cancelDrain()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), environmentVariables.ShutdownTimeout)
+	defer cancel()
+	if err := newPort.GinServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down the server: %v", err)
+	}
+	select {
+	case <-schedulerDone:
+	case <-drainCtx.Done():
+		log.Printf("Scheduler did not stop in time: %v", drainCtx.Err())
+	}
+	// Returning runs the deferred closes of the DB and Redis connections
 }
@@ -1,7 +1,61 @@
 package embedding
 
+import "time"
+
+// Distance metrics embeddings can be compared with
+const (
+	MetricCosine       = "cosine"
+	MetricL2           = "l2"
+	MetricInnerProduct = "inner_product"
+)
+
+// Content types embeddings are stored for, each with its own threshold
+const (
+	ContentTopic = "topic"
+	ContentTweet = "tweet"
+)
+
 type Embedding struct {
 	ID         string
 	Input      string
 	Embeddings string
 }
+
+// Match is the stored value nearest to an embedding. Score is the cosine
+// similarity or inner product, where higher is closer, or the L2 distance,
+// where lower is closer. Similar tells whether it passes the content type's threshold
+type Match struct {
+	ID          int       `json:"id"`
+	Value       string    `json:"value"`
+	ContentType string    `json:"contentType"`
+	Score       float64   `json:"score"`
+	Similar     bool      `json:"similar"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// IsSimilar tells whether a score under a metric is within a threshold
+func IsSimilar(metric string, score, threshold float64) bool {
+	if metric == MetricL2 {
+		return score <= threshold
+	}
+	return score >= threshold
+}
+
+// Result is a stored value found by a search, with the post it went out in
+// when it was published
+type Result struct {
+	Match
+	PostID    *int       `json:"postId,omitempty"`
+	PostedAt  *time.Time `json:"postedAt,omitempty"`
+	TweetURLs []string   `json:"tweetUrls,omitempty"`
+}
+
+type SearchParams struct {
+	Query       string `form:"q"           binding:"required,max=500"`
+	ContentType string `form:"contentType" binding:"omitempty,oneof=topic tweet"`
+	Limit       int    `form:"limit"       binding:"omitempty,min=1,max=50"`
+}
+
+type EmbeddingIDParams struct {
+	ID int `uri:"id" binding:"required"`
+}
@@ -0,0 +1,22 @@
+package embedding
+
+// CacheStats counts the embeddings served from the cache and those
+// requested from the provider since the app started
+type CacheStats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hitRate"`
+}
+
+// CacheCounter is a cache that counts its hits and misses
+type CacheCounter interface {
+	CacheStats() CacheStats
+}
+
+// CacheRepository persists cached embeddings by a key of the model and text
+// they were made from, so they outlive the Redis cache
+type CacheRepository interface {
+	// GetCached returns the embeddings stored under any of the keys
+	GetCached(keys []string) (map[string][]float32, error)
+	AddCached(model string, embeddings map[string][]float32) error
+}
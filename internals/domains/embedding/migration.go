@@ -0,0 +1,42 @@
+package embedding
+
+import "time"
+
+const (
+	MigrationRunning   = "running"
+	MigrationCompleted = "completed"
+	MigrationCancelled = "cancelled"
+)
+
+// Migration re-embeds every stored value and knowledge passage with another
+// model into shadow tables, which replace the live embeddings once nothing
+// is left to re-embed. The model of the last completed migration is the one
+// values are embedded with from then on
+type Migration struct {
+	ID          int        `json:"id"`
+	Model       string     `json:"model"`
+	Dimensions  int        `json:"dimensions"`
+	Status      string     `json:"status"`
+	Total       int        `json:"total"`
+	Done        int        `json:"done"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// Pending is a stored value, or a knowledge passage, still to be re-embedded
+type Pending struct {
+	ID      int
+	Value   string
+	Passage bool
+}
+
+type StartMigrationParams struct {
+	Model      string `json:"model"      binding:"required,max=64"`
+	Dimensions int    `json:"dimensions" binding:"required,min=1,max=4000"`
+}
+
+// Embedder embeds values with any model, where llm.Repository embeds with
+// the configured one
+type Embedder interface {
+	Embed(model string, dimensions int, values []string) ([][]float32, error)
+}
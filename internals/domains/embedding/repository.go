@@ -1,6 +1,33 @@
 package embedding
 
 type Repository interface {
-	AddEmbedding(embedding []float32, value string) error
-	SimilarValuesExist(embedding []float32) (*bool, error)
+	// AddEmbedding stores the embedding of a value of a content type and
+	// returns its id. postID links a published value to its post
+	AddEmbedding(embedding []float32, value, contentType string, postID *int) (int, error)
+	// Nearest returns the stored value of a content type nearest to an
+	// embedding within the lookback window, or nil when there is none. Only
+	// values embedded with the configured model are compared
+	Nearest(embedding []float32, contentType string) (*Match, error)
+	// Recent returns the latest stored values of a content type, newest first
+	Recent(contentType string, limit int) ([]Match, error)
+	// Search returns the stored values nearest to an embedding, closest
+	// first, over every content type when contentType is empty
+	Search(embedding []float32, contentType string, limit int) ([]Result, error)
+	// DeleteEmbedding forgets a stored value, so similar values are no longer blocked
+	DeleteEmbedding(id int) error
+
+	StartMigration(migration *Migration) error
+	// GetMigration returns the running migration, or nil when there is none
+	GetMigration() (*Migration, error)
+	// PendingEmbeddings returns values and passages the running migration
+	// has not re-embedded yet, values first
+	PendingEmbeddings(limit int) ([]Pending, error)
+	// AddShadowEmbeddings stores re-embedded values and passages by their ids
+	AddShadowEmbeddings(migrationID int, embeddings, passages map[int][]float32) error
+	// CutOver swaps the re-embedded values in for the live ones and completes the migration
+	CutOver(migration *Migration) error
+	CancelMigration(migration *Migration) error
+	// ActiveModel returns the last migration to cut over, whose model values
+	// are embedded with, or nil when none has
+	ActiveModel() (*Migration, error)
 }
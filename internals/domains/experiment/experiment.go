@@ -0,0 +1,77 @@
+package experiment
+
+import "time"
+
+const (
+	StatusRunning  = "running"
+	StatusStopped  = "stopped"
+	StatusPromoted = "promoted"
+)
+
+// Experiment splits generation traffic for one prompt template between variants
+type Experiment struct {
+	ID              int        `json:"id"`
+	Name            string     `json:"name"`
+	Template        string     `json:"template"`
+	Status          string     `json:"status"`
+	WinnerVariantID *int       `json:"winnerVariantId,omitempty"`
+	Variants        []Variant  `json:"variants"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	StoppedAt       *time.Time `json:"stoppedAt,omitempty"`
+}
+
+// Variant is an alternative prompt body, written as a text/template over prompt.Data
+type Variant struct {
+	ID           int    `json:"id"`
+	ExperimentID int    `json:"experimentId"`
+	Name         string `json:"name"`
+	Body         string `json:"body"`
+	Weight       int    `json:"weight"`
+}
+
+// VariantMetrics is the latest engagement of every post a variant produced, summed
+type VariantMetrics struct {
+	VariantID   int
+	Posts       int
+	Impressions int
+	Engagements int
+}
+
+// Result compares a variant with the control, the experiment's first variant
+type Result struct {
+	VariantID      int     `json:"variantId"`
+	Name           string  `json:"name"`
+	Control        bool    `json:"control"`
+	Posts          int     `json:"posts"`
+	Impressions    int     `json:"impressions"`
+	Engagements    int     `json:"engagements"`
+	EngagementRate float64 `json:"engagementRate"`
+	Lift           float64 `json:"lift"`
+	ZScore         float64 `json:"zScore"`
+	PValue         float64 `json:"pValue"`
+	Significant    bool    `json:"significant"`
+	// InsufficientData is set when a variant, or the control it is compared
+	// to, has no impressions or counts that can't be a rate
+	InsufficientData bool `json:"insufficientData"`
+}
+
+type VariantParams struct {
+	Name   string `json:"name"   binding:"required,max=64"`
+	Body   string `json:"body"   binding:"required"`
+	Weight int    `json:"weight" binding:"required,min=1"`
+}
+
+type CreateExperimentParams struct {
+	Name     string          `json:"name"     binding:"required,max=128"`
+	Template string          `json:"template" binding:"required,oneof=short_tweet tweet_thread poll"`
+	Variants []VariantParams `json:"variants" binding:"required,min=2,dive"`
+}
+
+type ExperimentIDParams struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type PromoteExperimentParams struct {
+	ID        int
+	VariantID int `json:"variantId" binding:"required"`
+}
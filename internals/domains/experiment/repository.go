@@ -0,0 +1,11 @@
+package experiment
+
+type Repository interface {
+	AddExperiment(experiment *Experiment) error
+	GetExperiment(id int) (*Experiment, error)
+	GetExperiments() ([]Experiment, error)
+	// GetActiveExperiment returns the running or promoted experiment for a template, or nil
+	GetActiveExperiment(template string) (*Experiment, error)
+	UpdateExperiment(experiment *Experiment) error
+	GetVariantMetrics(experimentID int) ([]VariantMetrics, error)
+}
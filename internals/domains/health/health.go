@@ -0,0 +1,34 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker checks that one dependency is reachable and usable. Check must
+// give up when ctx is done
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// Component is the outcome of one check. Results are cached, so CheckedAt
+// can be older than the request
+type Component struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMS int64     `json:"latencyMs"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Report is up only when every component is
+type Report struct {
+	Status     string      `json:"status"`
+	Components []Component `json:"components"`
+}
@@ -0,0 +1,26 @@
+package knowledge
+
+import "time"
+
+// Passage is a vetted piece of Polkadot reference material the bot can ground its content on
+type Passage struct {
+	ID        int       `json:"id"`
+	Source    string    `json:"source"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ScoredPassage struct {
+	Passage
+	Similarity float64 `json:"similarity"`
+}
+
+type AddPassageParams struct {
+	Content string `json:"content" binding:"required"`
+	Source  string `json:"source"`
+}
+
+type SearchParams struct {
+	Query string `form:"q"     binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
@@ -0,0 +1,6 @@
+package knowledge
+
+type Repository interface {
+	AddPassage(params *AddPassageParams, embedding []float32) error
+	NearestPassages(embedding []float32, limit int) ([]ScoredPassage, error)
+}
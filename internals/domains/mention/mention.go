@@ -0,0 +1,48 @@
+package mention
+
+import "time"
+
+const (
+	QUESTION = "question"
+	PRAISE   = "praise"
+	SPAM     = "spam"
+	HOSTILE  = "hostile"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusReplied  = "replied"
+	StatusIgnored  = "ignored"
+	StatusRejected = "rejected"
+)
+
+// Mention is a post that mentions the bot, with its classification and drafted reply
+type Mention struct {
+	ID             int       `json:"id"`
+	TweetID        string    `json:"tweetId"`
+	AuthorID       string    `json:"authorId"`
+	AuthorUsername string    `json:"authorUsername"`
+	Text           string    `json:"text"`
+	Classification string    `json:"classification"`
+	Confidence     float64   `json:"confidence"`
+	Draft          string    `json:"draft"`
+	Status         string    `json:"status"`
+	ReplyTweetID   string    `json:"replyTweetId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type GetMentionsParams struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved replied ignored rejected"`
+	Limit  int    `form:"limit"  binding:"omitempty,min=1,max=100"`
+}
+
+type MentionIDParams struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type ApproveMentionParams struct {
+	ID    int
+	Draft string `json:"draft"`
+}
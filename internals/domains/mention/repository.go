@@ -0,0 +1,9 @@
+package mention
+
+type Repository interface {
+	AddMention(mention *Mention) error
+	GetMention(id int) (*Mention, error)
+	GetMentions(params *GetMentionsParams) ([]Mention, error)
+	UpdateMention(mention *Mention) error
+	LatestTweetID() (string, error)
+}
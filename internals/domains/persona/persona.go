@@ -0,0 +1,92 @@
+package persona
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Persona is a brand voice generation prompts are written in
+type Persona struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	Tone             string    `json:"tone"`
+	ReadingLevel     string    `json:"readingLevel"`
+	BannedWords      []string  `json:"bannedWords"`
+	MaxHashtags      int       `json:"maxHashtags"`
+	MaxEmojis        int       `json:"maxEmojis"`
+	SignaturePhrases []string  `json:"signaturePhrases"`
+	ExamplePosts     []string  `json:"examplePosts"`
+	Categories       []string  `json:"categories"`
+	IsDefault        bool      `json:"isDefault"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// Instructions is the persona as a prompt section
+func (p *Persona) Instructions() string {
+	var section strings.Builder
+	fmt.Fprintf(&section, "## Persona: %s\n", p.Name)
+	if p.Description != "" {
+		fmt.Fprintf(&section, "%s\n", p.Description)
+	}
+	if p.Tone != "" {
+		fmt.Fprintf(&section, "\n## Tone\n%s\n", p.Tone)
+	}
+	if p.ReadingLevel != "" {
+		fmt.Fprintf(&section, "\n## Reading Level\n%s\n", p.ReadingLevel)
+	}
+
+	section.WriteString("\n## Hashtag and Emoji Policy\n")
+	if p.MaxHashtags == 0 {
+		section.WriteString("- Do not use any hashtags\n")
+	} else {
+		fmt.Fprintf(&section, "- Use at most %d hashtags\n", p.MaxHashtags)
+	}
+	if p.MaxEmojis == 0 {
+		section.WriteString("- Do not use any emoji\n")
+	} else {
+		fmt.Fprintf(&section, "- Use at most %d emoji\n", p.MaxEmojis)
+	}
+
+	if len(p.BannedWords) > 0 {
+		fmt.Fprintf(&section, "\n## Banned Words\nNever use these words: %s\n", strings.Join(p.BannedWords, ", "))
+	}
+	if len(p.SignaturePhrases) > 0 {
+		section.WriteString("\n## Signature Phrases\nWork these in where they fit naturally:\n")
+		for _, phrase := range p.SignaturePhrases {
+			fmt.Fprintf(&section, "- \"%s\"\n", phrase)
+		}
+	}
+	if len(p.ExamplePosts) > 0 {
+		section.WriteString("\n## Example Posts in This Voice\n")
+		for _, example := range p.ExamplePosts {
+			fmt.Fprintf(&section, "- \"%s\"\n", example)
+		}
+	}
+	return strings.TrimRight(section.String(), "\n")
+}
+
+type PersonaParams struct {
+	Name             string   `json:"name"             binding:"required,max=64"`
+	Description      string   `json:"description"`
+	Tone             string   `json:"tone"`
+	ReadingLevel     string   `json:"readingLevel"`
+	BannedWords      []string `json:"bannedWords"`
+	MaxHashtags      int      `json:"maxHashtags"      binding:"min=0,max=5"`
+	MaxEmojis        int      `json:"maxEmojis"        binding:"min=0,max=5"`
+	SignaturePhrases []string `json:"signaturePhrases"`
+	ExamplePosts     []string `json:"examplePosts"`
+	Categories       []string `json:"categories"`
+	IsDefault        bool     `json:"isDefault"`
+}
+
+type PersonaIDParams struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type UpdatePersonaParams struct {
+	ID int
+	PersonaParams
+}
@@ -0,0 +1,58 @@
+package persona
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// EmojiPattern matches a single emoji
+var EmojiPattern = regexp.MustCompile(`[\x{1F600}-\x{1F64F}\x{1F300}-\x{1F5FF}\x{1F680}-\x{1F6FF}\x{1F700}-\x{1F77F}\x{1F780}-\x{1F7FF}\x{1F800}-\x{1F8FF}\x{1F900}-\x{1F9FF}\x{1FA00}-\x{1FA6F}\x{1FA70}-\x{1FAFF}\x{2600}-\x{26FF}\x{2700}-\x{27BF}\x{2300}-\x{23FF}\x{2B50}\x{2B06}\x{1F004}-\x{1F0CF}]`)
+
+// HashtagPattern matches a single hashtag
+var HashtagPattern = regexp.MustCompile(`#[\p{L}\p{N}_]+`)
+
+var repeatedSpaces = regexp.MustCompile(`[ \t]{2,}`)
+
+// Enforce removes emoji and hashtags past the persona's limits and rejects
+// text that uses one of its banned words. A nil persona allows anything.
+func (p *Persona) Enforce(text string) (string, error) {
+	if p == nil {
+		return text, nil
+	}
+
+	text = keepFirst(text, EmojiPattern, p.MaxEmojis)
+	text = keepFirst(text, HashtagPattern, p.MaxHashtags)
+	text = strings.TrimSpace(repeatedSpaces.ReplaceAllString(text, " "))
+
+	if word := p.BannedWord(text); word != "" {
+		return "", fmt.Errorf("text uses banned word %q of persona %s", word, p.Name)
+	}
+	return text, nil
+}
+
+// BannedWord returns the first banned word used in text as a whole word, ignoring case
+func (p *Persona) BannedWord(text string) string {
+	for _, word := range p.BannedWords {
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+		pattern := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(word) + `($|[^\p{L}\p{N}])`)
+		if pattern.MatchString(text) {
+			return word
+		}
+	}
+	return ""
+}
+
+func keepFirst(text string, pattern *regexp.Regexp, limit int) string {
+	count := 0
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		count++
+		if count > limit {
+			return ""
+		}
+		return match
+	})
+}
@@ -0,0 +1,59 @@
+package persona
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersona_Enforce(t *testing.T) {
+	tests := []struct {
+		name    string
+		persona *Persona
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "strips emoji and hashtags by default",
+			persona: &Persona{Name: "degen"},
+			text:    "gm fam 🚀 parachains are live #Polkadot #DOT",
+			want:    "gm fam parachains are live",
+		},
+		{
+			name:    "keeps emoji and hashtags up to the limit",
+			persona: &Persona{Name: "friendly", MaxEmojis: 1, MaxHashtags: 1},
+			text:    "Staking made simple 🎉🎉 #Polkadot #DOT",
+			want:    "Staking made simple 🎉 #Polkadot",
+		},
+		{
+			name:    "rejects a banned word in any case",
+			persona: &Persona{Name: "developer", BannedWords: []string{"wagmi"}},
+			text:    "XCM v4 is out, WAGMI",
+			wantErr: true,
+		},
+		{
+			name:    "matches banned words as whole words only",
+			persona: &Persona{Name: "friendly", BannedWords: []string{"ape"}},
+			text:    "Shape your governance vote",
+			want:    "Shape your governance vote",
+		},
+		{
+			name:    "nil persona allows anything",
+			persona: nil,
+			text:    "LFG 🚀",
+			want:    "LFG 🚀",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.persona.Enforce(tt.text)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package persona
+
+type Repository interface {
+	AddPersona(persona *Persona) error
+	GetPersona(id int) (*Persona, error)
+	GetPersonas() ([]Persona, error)
+	// GetPersonaForCategory returns the persona mapped to a topic category, or the default persona
+	GetPersonaForCategory(category string) (*Persona, error)
+	UpdatePersona(persona *Persona) error
+	DeletePersona(id int) error
+}
@@ -0,0 +1,191 @@
+package plan
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Slot is one planned post: when it goes out and what it is about. Product
+// slots leave the category to the project registry
+type Slot struct {
+	PostTime  time.Time `json:"postTime"`
+	TopicType string    `json:"topicType"`
+	Category  string    `json:"category,omitempty"`
+	Format    string    `json:"format"`
+}
+
+// Plan is a day's slots, in posting order, and the seed they were drawn with
+type Plan struct {
+	Date  string `json:"date"`
+	Seed  int64  `json:"seed"`
+	Slots []Slot `json:"slots"`
+}
+
+// Mix is the share of a day's posts every topic type, standard category and
+// format should get. Shares are relative weights and need not add up to one
+type Mix struct {
+	TopicTypes map[string]float64
+	Categories map[string]float64
+	Formats    map[string]float64
+	// MaxPerDay caps how many posts of a format go out in a day
+	MaxPerDay map[string]int
+	// FallbackFormat is used once every format of the mix has reached its
+	// cap. Slots are skipped when it has reached its own too
+	FallbackFormat string
+	// NoRepeatCategory keeps two posts in a row from sharing a category
+	NoRepeatCategory bool
+	// StandardType is the topic type whose slots are given a category
+	StandardType string
+}
+
+type GetPlanParams struct {
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// DaySeed derives a seed from a date, so every day gets a different plan but
+// planning the same day again gives the same one
+func DaySeed(date string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(date))
+	return int64(hash.Sum64() >> 1)
+}
+
+// NewPlan spreads the mix over a day's posting times. The same mix, times
+// and seed always give the same plan
+func NewPlan(date string, mix *Mix, times []time.Time, seed int64) *Plan {
+	random := rand.New(rand.NewSource(seed))
+	sorted := append([]time.Time(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	slots := make([]Slot, len(sorted))
+	types := newQuota(mix.TopicTypes, len(slots))
+	for i := range slots {
+		slots[i].PostTime = sorted[i]
+		slots[i].TopicType = types.draw(random, nil)
+	}
+
+	standard := 0
+	for _, slot := range slots {
+		if slot.TopicType == mix.StandardType {
+			standard++
+		}
+	}
+	categories := newQuota(mix.Categories, standard)
+	previous := ""
+	for i := range slots {
+		if slots[i].TopicType == mix.StandardType {
+			var allowed func(string) bool
+			if mix.NoRepeatCategory {
+				last := previous
+				allowed = func(category string) bool { return category != last }
+			}
+			slots[i].Category = categories.draw(random, allowed)
+		}
+		previous = slots[i].Category
+	}
+
+	// Caps are never passed, unlike the other rules
+	formats := newQuota(mix.Formats, len(slots))
+	uncapped := func(format string) bool {
+		limit, ok := mix.MaxPerDay[format]
+		return !ok || formats.used[format] < limit
+	}
+	planned := slots[:0]
+	for _, slot := range slots {
+		format, ok := formats.drawAllowed(random, uncapped)
+		if !ok {
+			if mix.FallbackFormat == "" || !uncapped(mix.FallbackFormat) {
+				continue
+			}
+			format = mix.FallbackFormat
+			formats.used[format]++
+		}
+		slot.Format = format
+		planned = append(planned, slot)
+	}
+
+	return &Plan{Date: date, Seed: seed, Slots: planned}
+}
+
+// quota tracks how far every value is from its share of a number of draws
+type quota struct {
+	keys    []string
+	targets map[string]float64
+	weights map[string]float64
+	used    map[string]int
+}
+
+func newQuota(weights map[string]float64, draws int) *quota {
+	q := &quota{targets: map[string]float64{}, weights: map[string]float64{}, used: map[string]int{}}
+	total := 0.0
+	for key, weight := range weights {
+		if weight > 0 {
+			q.keys = append(q.keys, key)
+			q.weights[key] = weight
+			total += weight
+		}
+	}
+	// Map order is random, and the draws must not be
+	sort.Strings(q.keys)
+	for _, key := range q.keys {
+		q.targets[key] = float64(draws) * q.weights[key] / total
+	}
+	return q
+}
+
+// draw picks a value at random, weighted by how far below its share it is.
+// Once every allowed value has had its share they are drawn by weight alone,
+// and when no value is allowed the rule is dropped rather than the slot
+func (q *quota) draw(random *rand.Rand, allowed func(string) bool) string {
+	if len(q.keys) == 0 {
+		return ""
+	}
+	if chosen, ok := q.drawAllowed(random, allowed); ok {
+		return chosen
+	}
+	return q.pick(random, q.keys)
+}
+
+// drawAllowed is draw without dropping the rule: it reports false when no
+// value is allowed
+func (q *quota) drawAllowed(random *rand.Rand, allowed func(string) bool) (string, bool) {
+	var candidates []string
+	for _, key := range q.keys {
+		if allowed == nil || allowed(key) {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return q.pick(random, candidates), true
+}
+
+func (q *quota) pick(random *rand.Rand, candidates []string) string {
+	weights := make([]float64, len(candidates))
+	total := 0.0
+	for i, key := range candidates {
+		weights[i] = max(q.targets[key]-float64(q.used[key]), 0)
+		total += weights[i]
+	}
+	if total == 0 {
+		for i, key := range candidates {
+			weights[i] = q.weights[key]
+			total += weights[i]
+		}
+	}
+
+	chosen := candidates[len(candidates)-1]
+	pick := random.Float64() * total
+	for i, key := range candidates {
+		if pick < weights[i] {
+			chosen = key
+			break
+		}
+		pick -= weights[i]
+	}
+	q.used[chosen]++
+	return chosen
+}
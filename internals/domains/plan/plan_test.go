@@ -0,0 +1,100 @@
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dayTimes(count int) []time.Time {
+	start := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	times := make([]time.Time, count)
+	for i := range times {
+		// Out of order, as the windows hand them over
+		times[i] = start.Add(time.Duration(count-i) * 40 * time.Minute)
+	}
+	return times
+}
+
+func testMix() *Mix {
+	return &Mix{
+		TopicTypes:       map[string]float64{"product": 1, "standard": 3},
+		Categories:       map[string]float64{"governance": 1, "interoperability": 1, "developers": 2},
+		Formats:          map[string]float64{"short": 2, "thread": 1, "poll": 1},
+		MaxPerDay:        map[string]int{"thread": 2},
+		NoRepeatCategory: true,
+		StandardType:     "standard",
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	p := NewPlan("2025-03-01", testMix(), dayTimes(16), 42)
+	assert.Len(t, p.Slots, 16)
+	assert.Equal(t, int64(42), p.Seed)
+
+	topicTypes := map[string]int{}
+	formats := map[string]int{}
+	for i, slot := range p.Slots {
+		topicTypes[slot.TopicType]++
+		formats[slot.Format]++
+		if i > 0 {
+			assert.True(t, slot.PostTime.After(p.Slots[i-1].PostTime))
+			if slot.Category != "" {
+				assert.NotEqual(t, p.Slots[i-1].Category, slot.Category, "slot %d repeats a category", i)
+			}
+		}
+		if slot.TopicType == "standard" {
+			assert.NotEmpty(t, slot.Category)
+		} else {
+			assert.Empty(t, slot.Category)
+		}
+	}
+
+	// Shares are met to within a slot, and caps are never passed
+	assert.Equal(t, map[string]int{"product": 4, "standard": 12}, topicTypes)
+	assert.Equal(t, 2, formats["thread"])
+	assert.InDelta(t, 8, formats["short"], 1)
+	assert.InDelta(t, 6, formats["poll"], 1)
+}
+
+func TestNewPlan_deterministic(t *testing.T) {
+	times := dayTimes(12)
+	assert.Equal(t, NewPlan("2025-03-01", testMix(), times, 7), NewPlan("2025-03-01", testMix(), times, 7))
+	assert.NotEqual(t, NewPlan("2025-03-01", testMix(), times, 7).Slots, NewPlan("2025-03-01", testMix(), times, 8).Slots)
+	assert.Equal(t, DaySeed("2025-03-01"), DaySeed("2025-03-01"))
+	assert.NotEqual(t, DaySeed("2025-03-01"), DaySeed("2025-03-02"))
+}
+
+func TestNewPlan_impossibleRules(t *testing.T) {
+	mix := &Mix{
+		TopicTypes:       map[string]float64{"standard": 1},
+		Categories:       map[string]float64{"governance": 1},
+		Formats:          map[string]float64{"thread": 1},
+		MaxPerDay:        map[string]int{"thread": 1},
+		NoRepeatCategory: true,
+		StandardType:     "standard",
+	}
+
+	// Rules that can't be kept are dropped instead of the slots, except for
+	// caps: slots past them fall back to another format
+	mix.FallbackFormat = "short"
+	p := NewPlan("2025-03-01", mix, dayTimes(3), 1)
+	if assert.Len(t, p.Slots, 3) {
+		for i, slot := range p.Slots {
+			format := "short"
+			if i == 0 {
+				format = "thread"
+			}
+			assert.Equal(t, Slot{PostTime: slot.PostTime, TopicType: "standard", Category: "governance", Format: format}, slot)
+		}
+	}
+
+	// or are skipped once the fallback has reached its cap too
+	mix.MaxPerDay["short"] = 1
+	p = NewPlan("2025-03-01", mix, dayTimes(3), 1)
+	if assert.Len(t, p.Slots, 2) {
+		assert.Equal(t, "thread", p.Slots[0].Format)
+		assert.Equal(t, "short", p.Slots[1].Format)
+	}
+}
@@ -0,0 +1,7 @@
+package plan
+
+type Repository interface {
+	SavePlan(plan *Plan) error
+	// GetPlan returns the plan of a day, formatted as 2006-01-02
+	GetPlan(date string) (*Plan, error)
+}
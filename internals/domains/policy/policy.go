@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+)
+
+const (
+	Pass  = "pass"
+	Fix   = "fix"
+	Block = "block"
+)
+
+// Result is what a rule decided about a piece of text. A fix carries the
+// corrected text
+type Result struct {
+	Verdict string
+	Reason  string
+	Text    string
+}
+
+// Rule checks one piece of text: a tweet, a poll question or a poll option
+type Rule interface {
+	Name() string
+	Check(text string) (Result, error)
+}
+
+// Violation is a rule that fixed or blocked part of a draft
+type Violation struct {
+	Rule    string `json:"rule"`
+	Verdict string `json:"verdict"`
+	Reason  string `json:"reason"`
+	Tweet   int    `json:"tweet"`
+	Text    string `json:"text"`
+}
+
+// Report is the outcome of reviewing a draft: block if any rule blocked,
+// fix if it was corrected, otherwise pass
+type Report struct {
+	Verdict    string      `json:"verdict"`
+	Violations []Violation `json:"violations"`
+}
+
+// Blocked is a draft the policy kept from being published
+type Blocked struct {
+	ID         int         `json:"id"`
+	Draft      post.Draft  `json:"draft"`
+	Violations []Violation `json:"violations"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+type GetBlockedParams struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Review runs every rule over every text in a draft, applying fixes in place.
+// All violations are collected so a blocked draft shows everything wrong with it
+func (engine *Engine) Review(draft *post.Draft) (*Report, error) {
+	report := &Report{Verdict: Pass}
+	for i := range draft.Tweets {
+		tweet := &draft.Tweets[i]
+
+		text, err := engine.review(tweet.Text, i, report)
+		if err != nil {
+			return nil, err
+		}
+		tweet.Text = text
+
+		if tweet.Poll == nil {
+			continue
+		}
+		for j, option := range tweet.Poll.Options {
+			if tweet.Poll.Options[j], err = engine.review(option, i, report); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// ReviewText runs every rule over a text published on its own, like a reply
+// or quote commentary, returning it with the fixes applied
+func (engine *Engine) ReviewText(text string) (string, *Report, error) {
+	report := &Report{Verdict: Pass}
+	text, err := engine.review(text, 0, report)
+	if err != nil {
+		return "", nil, err
+	}
+	return text, report, nil
+}
+
+func (engine *Engine) review(text string, tweet int, report *Report) (string, error) {
+	for _, rule := range engine.rules {
+		result, err := rule.Check(text)
+		if err != nil {
+			return "", fmt.Errorf("policy rule %s failed: %v", rule.Name(), err)
+		}
+		switch result.Verdict {
+		case Pass:
+			continue
+		case Fix:
+			text = strings.TrimSpace(result.Text)
+			if report.Verdict == Pass {
+				report.Verdict = Fix
+			}
+		case Block:
+			report.Verdict = Block
+		default:
+			return "", fmt.Errorf("policy rule %s returned unknown verdict %q", rule.Name(), result.Verdict)
+		}
+		report.Violations = append(report.Violations, Violation{
+			Rule:    rule.Name(),
+			Verdict: result.Verdict,
+			Reason:  result.Reason,
+			Tweet:   tweet,
+			Text:    text,
+		})
+	}
+
+	if strings.TrimSpace(text) == "" {
+		report.Verdict = Block
+		report.Violations = append(report.Violations, Violation{Rule: "empty", Verdict: Block, Reason: "nothing left to post after fixes", Tweet: tweet})
+	}
+	return text, nil
+}
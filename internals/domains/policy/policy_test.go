@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	predictions, err := NewPatternRule("patterns", "looks like a price prediction", []string{`(?i)\bprice\s+targets?\b`, `(?i)\$\d[\d,.]*\s*[km]?\s+(soon|by)\b`})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		rule    Rule
+		text    string
+		verdict string
+		fixed   string
+	}{
+		{"pattern blocks", predictions, "DOT to $100 soon", Block, ""},
+		{"pattern passes", predictions, "Staking rewards are paid every era", Pass, ""},
+		{"keyword blocks whole words", NewKeywordRule("keywords", []string{"financial advice", "nfa"}), "Stake your DOT. NFA", Block, ""},
+		{"keyword ignores parts of words", NewKeywordRule("keywords", []string{"nfa"}), "Infrastructure matters", Pass, ""},
+		{"allowed link and subdomain", NewLinkRule([]string{"polkadot.network"}), "Read more at https://wiki.polkadot.network/docs/learn-staking.", Pass, ""},
+		{"other links are removed", NewLinkRule([]string{"polkadot.network"}), "Claim here https://dot-airdrop.xyz/claim now", Fix, "Claim here now"},
+		{"www links are checked too", NewLinkRule([]string{"polkadot.network"}), "See www.example.com", Fix, "See "},
+		{"verified ticker", NewCashtagRule([]string{"DOT", "$KSM"}), "$DOT and $ksm", Pass, ""},
+		{"unverified ticker", NewCashtagRule([]string{"DOT"}), "$DOT and $PEPE", Block, ""},
+		{"hashtags under the limit", NewHashtagRule(2), "Polkadot #DOT #Web3", Pass, ""},
+		{"hashtags over the limit", NewHashtagRule(1), "Polkadot #DOT #Web3 #XCM", Fix, "Polkadot #DOT "},
+		{"mentions under the limit", NewMentionRule(1), "Thanks @Polkadot", Pass, ""},
+		{"mentions over the limit", NewMentionRule(1), "Thanks @Polkadot and @web3foundation", Block, ""},
+		{"emails are not mentions", NewMentionRule(0), "Write to team@parity.io", Pass, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.rule.Check(tt.text)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.verdict, result.Verdict)
+			if tt.verdict != Pass {
+				assert.NotEmpty(t, result.Reason)
+			}
+			if tt.verdict == Fix {
+				assert.Equal(t, tt.fixed, result.Text)
+			}
+		})
+	}
+}
+
+func TestEngine_Review(t *testing.T) {
+	engine := NewEngine(NewLinkRule([]string{"polkadot.network"}), NewKeywordRule("keywords", []string{"buy now"}))
+
+	draft := &post.Draft{Tweets: []xdotcom.Tweet{
+		{Text: "Async backing is live https://polkadot.network/blog"},
+		{Text: "Details at https://example.com/thread"},
+	}}
+	report, err := engine.Review(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, Fix, report.Verdict)
+	assert.Equal(t, "Details at", draft.Tweets[1].Text)
+	assert.Len(t, report.Violations, 1)
+	assert.Equal(t, 1, report.Violations[0].Tweet)
+
+	draft = &post.Draft{Tweets: []xdotcom.Tweet{{
+		Text: "Which parachain should you back?",
+		Poll: &xdotcom.Poll{Options: []string{"Moonbeam", "Buy now"}},
+	}}}
+	report, err = engine.Review(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, Block, report.Verdict)
+	assert.Equal(t, "keywords", report.Violations[0].Rule)
+
+	draft = &post.Draft{Tweets: []xdotcom.Tweet{{Text: "https://example.com"}}}
+	report, err = engine.Review(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, Block, report.Verdict)
+}
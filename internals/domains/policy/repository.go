@@ -0,0 +1,6 @@
+package policy
+
+type Repository interface {
+	AddBlocked(blocked *Blocked) error
+	GetBlocked(limit int) ([]Blocked, error)
+}
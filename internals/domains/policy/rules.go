@@ -0,0 +1,225 @@
+package policy
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+)
+
+var (
+	linkPattern    = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s]+`)
+	cashtagPattern = regexp.MustCompile(`\$[A-Za-z][A-Za-z0-9]{1,9}\b`)
+	mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\w{1,15})\b`)
+	repeatedSpaces = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+func pass() (Result, error) {
+	return Result{Verdict: Pass}, nil
+}
+
+type patternRule struct {
+	name     string
+	reason   string
+	patterns []*regexp.Regexp
+}
+
+// NewPatternRule blocks text matching any of the regular expressions
+func NewPatternRule(name, reason string, patterns []string) (Rule, error) {
+	rule := &patternRule{name: name, reason: reason}
+	for _, pattern := range patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %v", name, pattern, err)
+		}
+		rule.patterns = append(rule.patterns, compiled)
+	}
+	return rule, nil
+}
+
+func (rule *patternRule) Name() string {
+	return rule.name
+}
+
+func (rule *patternRule) Check(text string) (Result, error) {
+	for _, pattern := range rule.patterns {
+		if match := pattern.FindString(text); match != "" {
+			return Result{Verdict: Block, Reason: fmt.Sprintf("%s: %q", rule.reason, match)}, nil
+		}
+	}
+	return pass()
+}
+
+type keyword struct {
+	word    string
+	pattern *regexp.Regexp
+}
+
+type keywordRule struct {
+	name     string
+	keywords []keyword
+}
+
+// NewKeywordRule blocks text using any of the keywords as whole words, ignoring case
+func NewKeywordRule(name string, keywords []string) Rule {
+	rule := &keywordRule{name: name}
+	for _, word := range keywords {
+		if word = strings.TrimSpace(word); word != "" {
+			rule.keywords = append(rule.keywords, keyword{
+				word:    word,
+				pattern: regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(word) + `($|[^\p{L}\p{N}])`),
+			})
+		}
+	}
+	return rule
+}
+
+func (rule *keywordRule) Name() string {
+	return rule.name
+}
+
+func (rule *keywordRule) Check(text string) (Result, error) {
+	for _, keyword := range rule.keywords {
+		if keyword.pattern.MatchString(text) {
+			return Result{Verdict: Block, Reason: fmt.Sprintf("uses %q", keyword.word)}, nil
+		}
+	}
+	return pass()
+}
+
+type linkRule struct {
+	allowed []string
+}
+
+// NewLinkRule removes links to hosts outside the allowed domains and their subdomains
+func NewLinkRule(allowed []string) Rule {
+	rule := &linkRule{}
+	for _, domain := range allowed {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			rule.allowed = append(rule.allowed, domain)
+		}
+	}
+	return rule
+}
+
+func (rule *linkRule) Name() string {
+	return "links"
+}
+
+func (rule *linkRule) allows(link string) bool {
+	link = strings.TrimRight(link, ".,!?;:)\"'")
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, domain := range rule.allowed {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *linkRule) Check(text string) (Result, error) {
+	var removed []string
+	fixed := linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		if rule.allows(link) {
+			return link
+		}
+		removed = append(removed, link)
+		return ""
+	})
+	if len(removed) == 0 {
+		return pass()
+	}
+	return Result{
+		Verdict: Fix,
+		Reason:  fmt.Sprintf("removed links outside the allow-list: %s", strings.Join(removed, ", ")),
+		Text:    repeatedSpaces.ReplaceAllString(fixed, " "),
+	}, nil
+}
+
+type cashtagRule struct {
+	allowed map[string]bool
+}
+
+// NewCashtagRule blocks $TICKER cashtags that are not on the verified list
+func NewCashtagRule(allowed []string) Rule {
+	rule := &cashtagRule{allowed: map[string]bool{}}
+	for _, ticker := range allowed {
+		rule.allowed[strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(ticker), "$"))] = true
+	}
+	return rule
+}
+
+func (rule *cashtagRule) Name() string {
+	return "tickers"
+}
+
+func (rule *cashtagRule) Check(text string) (Result, error) {
+	for _, cashtag := range cashtagPattern.FindAllString(text, -1) {
+		if !rule.allowed[strings.ToUpper(cashtag[1:])] {
+			return Result{Verdict: Block, Reason: fmt.Sprintf("unverified ticker %s", cashtag)}, nil
+		}
+	}
+	return pass()
+}
+
+type hashtagRule struct {
+	max int
+}
+
+// NewHashtagRule drops hashtags past the maximum
+func NewHashtagRule(max int) Rule {
+	return &hashtagRule{max: max}
+}
+
+func (rule *hashtagRule) Name() string {
+	return "hashtags"
+}
+
+func (rule *hashtagRule) Check(text string) (Result, error) {
+	count := 0
+	fixed := persona.HashtagPattern.ReplaceAllStringFunc(text, func(hashtag string) string {
+		count++
+		if count > rule.max {
+			return ""
+		}
+		return hashtag
+	})
+	if count <= rule.max {
+		return pass()
+	}
+	return Result{
+		Verdict: Fix,
+		Reason:  fmt.Sprintf("%d hashtags, at most %d are allowed", count, rule.max),
+		Text:    repeatedSpaces.ReplaceAllString(fixed, " "),
+	}, nil
+}
+
+type mentionRule struct {
+	max int
+}
+
+// NewMentionRule blocks text tagging more accounts than the maximum. Mentions
+// are part of the sentence, so they are not removed
+func NewMentionRule(max int) Rule {
+	return &mentionRule{max: max}
+}
+
+func (rule *mentionRule) Name() string {
+	return "mentions"
+}
+
+func (rule *mentionRule) Check(text string) (Result, error) {
+	if count := len(mentionPattern.FindAllString(text, -1)); count > rule.max {
+		return Result{Verdict: Block, Reason: fmt.Sprintf("%d mentions, at most %d are allowed", count, rule.max)}, nil
+	}
+	return pass()
+}
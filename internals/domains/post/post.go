@@ -0,0 +1,134 @@
+package post
+
+import (
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+)
+
+// Draft is generated content waiting to be published, with the choices that produced it
+type Draft struct {
+	Topic          string          `json:"topic"`
+	TopicType      string          `json:"topicType"`
+	Category       string          `json:"category"`
+	Format         string          `json:"format"`
+	PromptTemplate string          `json:"promptTemplate"`
+	PromptVersion  int             `json:"promptVersion"`
+	ExperimentID   *int            `json:"experimentId,omitempty"`
+	VariantID      *int            `json:"variantId,omitempty"`
+	PersonaID      *int            `json:"personaId,omitempty"`
+	CandidateBatch string          `json:"candidateBatch,omitempty"`
+	ProjectID      *int            `json:"projectId,omitempty"`
+	Tweets         []xdotcom.Tweet `json:"tweets"`
+	Claims         []Claim         `json:"claims,omitempty"`
+}
+
+// Post is a published draft and the IDs X gave its tweets
+type Post struct {
+	ID             int       `json:"id"`
+	Topic          string    `json:"topic"`
+	TopicType      string    `json:"topicType"`
+	Category       string    `json:"category"`
+	Format         string    `json:"format"`
+	PromptTemplate string    `json:"promptTemplate"`
+	PromptVersion  int       `json:"promptVersion"`
+	ExperimentID   *int      `json:"experimentId,omitempty"`
+	VariantID      *int      `json:"variantId,omitempty"`
+	PersonaID      *int      `json:"personaId,omitempty"`
+	CandidateBatch string    `json:"candidateBatch,omitempty"`
+	TweetIDs       []string  `json:"tweetIds"`
+	Claims         []Claim   `json:"claims,omitempty"`
+	PostedAt       time.Time `json:"postedAt"`
+}
+
+const (
+	ClaimSupported    = "supported"
+	ClaimUnsupported  = "unsupported"
+	ClaimContradicted = "contradicted"
+)
+
+// Claim is a factual statement made in a draft and what the knowledge base says about it
+type Claim struct {
+	Text       string `json:"text"`
+	Label      string `json:"label"`
+	Evidence   string `json:"evidence,omitempty"`
+	PassageIDs []int  `json:"passageIds,omitempty"`
+}
+
+// Candidate is one of the drafts generated for a slot and how it scored.
+// Every candidate of a slot shares a batch, and the selected one is published
+type Candidate struct {
+	ID             int                `json:"id"`
+	Batch          string             `json:"batch"`
+	Topic          string             `json:"topic"`
+	Format         string             `json:"format"`
+	PromptTemplate string             `json:"promptTemplate"`
+	Texts          []string           `json:"texts"`
+	Heuristics     map[string]float64 `json:"heuristics"`
+	Heuristic      float64            `json:"heuristic"`
+	Judge          *float64           `json:"judge,omitempty"`
+	JudgeReason    string             `json:"judgeReason,omitempty"`
+	Score          float64            `json:"score"`
+	Selected       bool               `json:"selected"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	Tweets         []xdotcom.Tweet    `json:"-"`
+}
+
+// Snapshot is the public metrics of one tweet at the time they were collected
+type Snapshot struct {
+	PostID      int       `json:"postId"`
+	TweetID     string    `json:"tweetId"`
+	CollectedAt time.Time `json:"collectedAt"`
+	xdotcom.PublicMetrics
+}
+
+const (
+	GroupByTopicType      = "topicType"
+	GroupByCategory       = "category"
+	GroupByFormat         = "format"
+	GroupByPromptTemplate = "promptTemplate"
+	GroupByHour           = "hour"
+	GroupByWeekday        = "weekday"
+)
+
+type GetPerformanceParams struct {
+	GroupBy  string `form:"groupBy" binding:"required,oneof=topicType category format promptTemplate hour weekday"`
+	Days     int    `form:"days"    binding:"omitempty,min=1,max=365"`
+	Timezone string `form:"-"`
+}
+
+// Performance is the latest metrics of every post in a group, summed
+type Performance struct {
+	Key            string  `json:"key"`
+	Posts          int     `json:"posts"`
+	Impressions    int     `json:"impressions"`
+	Likes          int     `json:"likes"`
+	Reposts        int     `json:"reposts"`
+	Replies        int     `json:"replies"`
+	Quotes         int     `json:"quotes"`
+	Bookmarks      int     `json:"bookmarks"`
+	EngagementRate float64 `json:"engagementRate"`
+}
+
+type GetSlotWeightsParams struct {
+	Weekday  string `form:"weekday" binding:"required,oneof=Sunday Monday Tuesday Wednesday Thursday Friday Saturday"`
+	Days     int    `form:"-"`
+	Timezone string `form:"-"`
+}
+
+// HourlyEngagement is the engagement of every post made in one hour of a weekday
+type HourlyEngagement struct {
+	Hour        int `json:"hour"`
+	Posts       int `json:"posts"`
+	Impressions int `json:"impressions"`
+	Engagements int `json:"engagements"`
+}
+
+// SlotWeight is how strongly the scheduler favours an hour and what the weight is made of
+type SlotWeight struct {
+	Hour           int     `json:"hour"`
+	Posts          int     `json:"posts"`
+	EngagementRate float64 `json:"engagementRate"`
+	Exploration    float64 `json:"exploration"`
+	Weight         float64 `json:"weight"`
+}
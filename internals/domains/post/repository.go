@@ -0,0 +1,12 @@
+package post
+
+import "time"
+
+type Repository interface {
+	AddPost(post *Post) error
+	AddCandidates(candidates []Candidate) error
+	GetPostsSince(since time.Time) ([]Post, error)
+	AddSnapshots(snapshots []Snapshot) error
+	GetPerformance(params *GetPerformanceParams) ([]Performance, error)
+	GetHourlyEngagement(params *GetSlotWeightsParams) ([]HourlyEngagement, error)
+}
@@ -0,0 +1,42 @@
+package project
+
+import "time"
+
+const (
+	StatusLive    = "live"
+	StatusTestnet = "testnet"
+	StatusSunset  = "sunset"
+)
+
+// Project is a Polkadot ecosystem project product topics are written about.
+// Sunset projects stay in the registry but are no longer featured
+type Project struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Category       string     `json:"category"`
+	Website        string     `json:"website"`
+	XHandle        string     `json:"xHandle"`
+	Status         string     `json:"status"`
+	Description    string     `json:"description"`
+	LastFeaturedAt *time.Time `json:"lastFeaturedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type ProjectParams struct {
+	Name        string `json:"name"        binding:"required,max=128"`
+	Category    string `json:"category"    binding:"max=64"`
+	Website     string `json:"website"     binding:"omitempty,url,max=256"`
+	XHandle     string `json:"xHandle"     binding:"max=64"`
+	Status      string `json:"status"      binding:"required,oneof=live testnet sunset"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+type ProjectIDParams struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type UpdateProjectParams struct {
+	ID int
+	ProjectParams
+}
@@ -0,0 +1,17 @@
+package project
+
+import "time"
+
+type Repository interface {
+	AddProject(project *Project) error
+	// ImportProjects adds the projects, replacing the details of any project
+	// already registered under the same name. It returns how many were imported
+	ImportProjects(projects []Project) (int, error)
+	GetProject(id int) (*Project, error)
+	GetProjects() ([]Project, error)
+	// GetFeaturable returns the projects that are not sunset
+	GetFeaturable() ([]Project, error)
+	UpdateProject(project *Project) error
+	DeleteProject(id int) error
+	MarkFeatured(id int, at time.Time) error
+}
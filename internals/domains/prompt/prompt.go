@@ -0,0 +1,53 @@
+package prompt
+
+import "time"
+
+const (
+	SourceFile     = "file"
+	SourceDatabase = "database"
+)
+
+// Template is one version of a named text/template prompt
+type Template struct {
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	Body      string    `json:"body"`
+	Source    string    `json:"source"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Limits are the length rules templates can quote, so prompts and validation agree
+type Limits struct {
+	MaxTweetLength      int
+	TweetCharacters     int
+	MaxThreadTweets     int
+	MinPollOptions      int
+	MaxPollOptions      int
+	MaxPollOptionLength int
+}
+
+// Data is what a template can reference
+type Data struct {
+	Topic   string
+	Context string
+	Product string
+	Persona string
+	Limits  Limits
+}
+
+// Rendered is a rendered prompt and the template version that produced it
+type Rendered struct {
+	Name    string
+	Version int
+	Text    string
+}
+
+type TemplateNameParams struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+type OverrideTemplateParams struct {
+	Name string
+	Body string `json:"body" binding:"required"`
+}
@@ -0,0 +1,18 @@
+package prompt
+
+type Repository interface {
+	// Render executes the active version of a template
+	Render(name string, data Data) (*Rendered, error)
+	GetTemplates() ([]Template, error)
+	// OverrideTemplate stores body as the next version of a template and activates it
+	OverrideTemplate(name, body string) (*Template, error)
+	// ResetTemplate drops the active override so the template file is used again
+	ResetTemplate(name string) error
+}
+
+// OverrideRepository persists the template versions created through the admin API
+type OverrideRepository interface {
+	AddOverride(template *Template) error
+	GetOverrides() ([]Template, error)
+	DeactivateOverrides(name string) error
+}
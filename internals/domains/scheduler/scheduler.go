@@ -0,0 +1,21 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+)
+
+// Quotas the scheduler draws from. Replies have their own, so they never
+// eat into original posts
+const (
+	QuotaTweets  = "daily_quota"
+	QuotaReplies = "reply_quota"
+)
+
+type ScheduledTweet struct {
+	PostTime time.Time
+	Executed bool
+	// Slot is what the content plan has the tweet post, if the day was planned
+	Slot *plan.Slot
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import "time"
+
+// Store keeps the scheduler's state between ticks and restarts: the daily
+// quotas, the day's schedule, usage stats, locks and the cursors and
+// cooldowns of curation and mentions
+type Store interface {
+	// Quota returns what is left of a quota, and false when it was never set
+	Quota(name string) (int, bool, error)
+	// InitQuota sets a quota unless it is already set
+	InitQuota(name string, remaining int) error
+	// ResetQuotas sets every quota at once and records when
+	ResetQuotas(quotas map[string]int, at time.Time) error
+	// LastReset returns when the quotas were last reset, or nil if never
+	LastReset() (*time.Time, error)
+	// ReserveQuota takes count from a quota, and false when not enough is left
+	ReserveQuota(name string, count int) (bool, error)
+
+	GetSchedule() ([]ScheduledTweet, error)
+	SetSchedule(schedule []ScheduledTweet) error
+
+	// AddUsage counts tweets posted on a date, kept for 30 days
+	AddUsage(date string, count int) error
+
+	// AcquireLock takes a lock for ttl, and false when someone else holds it
+	AcquireLock(name string, ttl time.Duration) (bool, error)
+	ReleaseLock(name string) error
+
+	// GetCursor returns the value of a cursor, or "" when it was never set
+	GetCursor(name string) (string, error)
+	SetCursor(name, value string) error
+
+	StartCooldown(name string, ttl time.Duration) error
+	CoolingDown(name string) (bool, error)
+
+	// Beat records that the scheduler loop is alive
+	Beat(at time.Time) error
+	// LastBeat returns when the loop last beat, or nil if never
+	LastBeat() (*time.Time, error)
+}
+
+// UsageRetention is how long daily usage stats are kept
+const UsageRetention = 30 * 24 * time.Hour
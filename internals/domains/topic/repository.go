@@ -0,0 +1,14 @@
+package topic
+
+type Repository interface {
+	// AddTopic queues a topic and reserves its embedding, unless a similar
+	// topic was already queued or tweeted. It reports whether it was added
+	AddTopic(topic *Topic, embedding []float32) (bool, error)
+	// NextTopic takes the next queued topic of a type off the backlog, or
+	// returns nil when it is empty. An empty category matches any category
+	NextTopic(topicType, category, order string) (*Topic, error)
+	CountQueued(topicType, category string) (int, error)
+	GetTopics(params *GetTopicsParams) ([]Topic, error)
+	UpdatePriority(id, priority int) error
+	DeleteTopic(id int) error
+}
@@ -0,0 +1,42 @@
+package topic
+
+import "time"
+
+const (
+	OrderFIFO     = "fifo"
+	OrderPriority = "priority"
+)
+
+// Topic is a generated or hand-picked subject waiting in the backlog. Higher
+// priorities are consumed first when the backlog is ordered by priority
+type Topic struct {
+	ID        int        `json:"id"`
+	Text      string     `json:"text"`
+	TopicType string     `json:"topicType"`
+	Category  string     `json:"category"`
+	Source    string     `json:"source"`
+	Priority  int        `json:"priority"`
+	ProjectID *int       `json:"projectId,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+}
+
+type AddTopicParams struct {
+	Text      string `json:"text"      binding:"required,max=280"`
+	TopicType string `json:"topicType" binding:"required,oneof=product standard"`
+	Category  string `json:"category"  binding:"max=128"`
+	Priority  int    `json:"priority"`
+}
+
+type TopicIDParams struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type UpdatePriorityParams struct {
+	ID       int `json:"-"`
+	Priority int `json:"priority"`
+}
+
+type GetTopicsParams struct {
+	TopicType string `form:"topicType" binding:"omitempty,oneof=product standard"`
+}
@@ -2,4 +2,9 @@ package xdotcom
 
 type Repository interface {
 	Tweet(tweet Tweet) (string, error)
+	UploadMedia(media Media) (string, error)
+	Retweet(tweetID string) error
+	UserTimeline(username string, sinceID string) ([]Post, error)
+	Mentions(sinceID string) ([]Post, error)
+	PublicMetrics(tweetIDs []string) (map[string]PublicMetrics, error)
 }
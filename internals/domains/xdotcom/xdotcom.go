@@ -1,6 +1,47 @@
 package xdotcom
 
+import "time"
+
+// StatusURL links to a tweet by its ID alone, without its author's handle
+func StatusURL(id string) string {
+	return "https://x.com/i/status/" + id
+}
+
 type Tweet struct {
-	Text            string
-	PreviousTweetID string
+	Text            string  `json:"text"`
+	PreviousTweetID string  `json:"previousTweetId,omitempty"`
+	QuoteTweetID    string  `json:"quoteTweetId,omitempty"`
+	Media           []Media `json:"media,omitempty"`
+	Poll            *Poll   `json:"poll,omitempty"`
+}
+
+type Media struct {
+	ID       string `json:"id,omitempty"`
+	Data     []byte `json:"-"`
+	MimeType string `json:"mimeType"`
+	AltText  string `json:"altText,omitempty"`
+}
+
+type Poll struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"durationMinutes"`
+}
+
+// Post is a tweet read back from X, e.g. from another account's timeline
+type Post struct {
+	ID             string    `json:"id"`
+	AuthorID       string    `json:"authorId"`
+	AuthorUsername string    `json:"authorUsername"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// PublicMetrics are the engagement counts X shows publicly on a tweet
+type PublicMetrics struct {
+	Impressions int `json:"impressions"`
+	Likes       int `json:"likes"`
+	Reposts     int `json:"reposts"`
+	Replies     int `json:"replies"`
+	Quotes      int `json:"quotes"`
+	Bookmarks   int `json:"bookmarks"`
 }
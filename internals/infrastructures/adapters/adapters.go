@@ -6,14 +6,41 @@ import (
 	"github.com/Pr3c10us/boilerplate/internals/domains/cache"
 	"github.com/Pr3c10us/boilerplate/internals/domains/email"
 	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
 	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
 	"github.com/Pr3c10us/boilerplate/internals/domains/sms"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
 	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
 	authentication2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/authentication"
 	cache2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/cache"
 	email2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/email"
 	embedding2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/embedding"
+	experiment2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/experiment"
+	health2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/health"
+	knowledge2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/llm/cached"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/llm/hashing"
 	openai2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/llm/openai"
+	mention2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/mention"
+	persona2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/persona"
+	plan2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/plan"
+	policy2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/policy"
+	post2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/post"
+	project2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/project"
+	prompt2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/prompt"
+	scheduler2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/scheduler"
+	sms2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/sms"
+	topic2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/topic"
 	xdotcom2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/xdotcom"
 	"github.com/Pr3c10us/boilerplate/packages/configs"
 	"github.com/Pr3c10us/boilerplate/packages/logger"
@@ -37,19 +64,167 @@ type Adapters struct {
 	SMSRepository            sms.Repository
 	CacheRepository          cache.Repository
 	OpenAiRepository         llm.Repository
+	JudgeRepository          llm.Repository
 	EmbeddingRepository      embedding.Repository
+	Embedder                 embedding.Embedder
+	EmbeddingCache           embedding.CacheCounter
 	XDotComRepository        xdotcom.Repository
+	KnowledgeRepository      knowledge.Repository
+	MentionRepository        mention.Repository
+	PostRepository           post.Repository
+	ExperimentRepository     experiment.Repository
+	PromptRepository         prompt.Repository
+	PersonaRepository        persona.Repository
+	PolicyRepository         policy.Repository
+	TopicRepository          topic.Repository
+	ProjectRepository        project.Repository
+	PlanRepository           plan.Repository
+	SchedulerStore           scheduler.Store
+	HealthCheckers           []health.Checker
 }
 
 func NewAdapters(dependencies AdapterDependencies) *Adapters {
-	return &Adapters{
-		Logger:                   dependencies.Logger,
-		EnvironmentVariables:     dependencies.EnvironmentVariables,
-		AuthenticationRepository: authentication2.NewAuthenticationRepositoryPG(dependencies.DB),
-		EmailRepository:          email2.NewGoMailEmailRepository(dependencies.EnvironmentVariables),
-		CacheRepository:          cache2.NewRedisRepository(dependencies.Redis, dependencies.EnvironmentVariables),
-		OpenAiRepository:         openai2.NewOpenAIRepository(dependencies.OpenAI),
-		EmbeddingRepository:      embedding2.NewEmbedding(dependencies.DB),
-		XDotComRepository:        xdotcom2.NewXDotComRepository(dependencies.EnvironmentVariables),
+	memory := dependencies.EnvironmentVariables.AdapterMode == configs.AdapterModeMemory
+	var cacheRepository cache.Repository
+	if memory {
+		cacheRepository = cache2.NewMemoryRepository()
+	} else {
+		cacheRepository = cache2.NewRedisRepository(dependencies.Redis, dependencies.EnvironmentVariables)
 	}
+	embeddingCache := newEmbeddingCache(dependencies, cacheRepository, memory)
+
+	adapters := &Adapters{
+		Logger:               dependencies.Logger,
+		EnvironmentVariables: dependencies.EnvironmentVariables,
+		CacheRepository:      cacheRepository,
+		OpenAiRepository:     newLLMRepository(dependencies, dependencies.EnvironmentVariables.OpenAIModel, embeddingCache),
+		JudgeRepository:      newLLMRepository(dependencies, dependencies.EnvironmentVariables.Candidates.JudgeModel, embeddingCache),
+		Embedder:             embeddingCache,
+		EmbeddingCache:       embeddingCache,
+	}
+	if memory {
+		adapters.useMemory()
+	} else {
+		adapters.usePG(dependencies)
+	}
+	adapters.HealthCheckers = newHealthCheckers(dependencies, adapters.SchedulerStore, memory)
+	return adapters
+}
+
+// usePG keeps state in Postgres and Redis and talks to SMTP and X
+func (adapters *Adapters) usePG(dependencies AdapterDependencies) {
+	adapters.AuthenticationRepository = authentication2.NewAuthenticationRepositoryPG(dependencies.DB)
+	adapters.EmailRepository = email2.NewGoMailEmailRepository(dependencies.EnvironmentVariables)
+	adapters.EmbeddingRepository = embedding2.NewEmbedding(dependencies.DB, dependencies.EnvironmentVariables.VectorSearch, dependencies.EnvironmentVariables.Embeddings)
+	adapters.XDotComRepository = xdotcom2.NewXDotComRepository(dependencies.EnvironmentVariables)
+	adapters.KnowledgeRepository = knowledge2.NewKnowledgeRepositoryPG(dependencies.DB, dependencies.EnvironmentVariables.Embeddings)
+	adapters.MentionRepository = mention2.NewMentionRepositoryPG(dependencies.DB)
+	adapters.PostRepository = post2.NewPostRepositoryPG(dependencies.DB)
+	adapters.ExperimentRepository = experiment2.NewExperimentRepositoryPG(dependencies.DB)
+	adapters.PromptRepository = prompt2.NewPromptRepository(dependencies.EnvironmentVariables.PromptsDir, prompt2.NewOverridesPG(dependencies.DB))
+	adapters.PersonaRepository = persona2.NewPersonaRepositoryPG(dependencies.DB)
+	adapters.PolicyRepository = policy2.NewPolicyRepositoryPG(dependencies.DB)
+	adapters.TopicRepository = topic2.NewTopicRepositoryPG(dependencies.DB, dependencies.EnvironmentVariables.VectorSearch, dependencies.EnvironmentVariables.Embeddings)
+	adapters.ProjectRepository = project2.NewProjectRepositoryPG(dependencies.DB)
+	adapters.PlanRepository = newPlanRepository(dependencies)
+	adapters.SchedulerStore = newSchedulerStore(dependencies)
+}
+
+// useMemory keeps all state in memory, so the app runs without Postgres,
+// Redis, SMTP, SNS or X. Nothing survives a restart
+func (adapters *Adapters) useMemory() {
+	knowledge := knowledge2.NewMemoryRepository(adapters.EnvironmentVariables.Embeddings)
+	embeddings := embedding2.NewMemoryRepository(adapters.EnvironmentVariables.VectorSearch, adapters.EnvironmentVariables.Embeddings, knowledge)
+	posts := post2.NewMemoryRepository()
+
+	adapters.AuthenticationRepository = authentication2.NewMemoryRepository()
+	adapters.EmailRepository = email2.NewMemoryRepository()
+	adapters.SMSRepository = sms2.NewMemoryRepository()
+	adapters.EmbeddingRepository = embeddings
+	adapters.XDotComRepository = xdotcom2.NewMemoryRepository()
+	adapters.KnowledgeRepository = knowledge
+	adapters.MentionRepository = mention2.NewMemoryRepository()
+	adapters.PostRepository = posts
+	adapters.ExperimentRepository = experiment2.NewMemoryRepository(posts)
+	adapters.PromptRepository = prompt2.NewPromptRepository(adapters.EnvironmentVariables.PromptsDir, prompt2.NewOverridesMemory())
+	adapters.PersonaRepository = persona2.NewMemoryRepository()
+	adapters.PolicyRepository = policy2.NewMemoryRepository()
+	adapters.TopicRepository = topic2.NewMemoryRepository(embeddings)
+	adapters.ProjectRepository = project2.NewMemoryRepository()
+	adapters.PlanRepository = plan2.NewMemoryRepository()
+	adapters.SchedulerStore = scheduler2.NewMemoryStore()
+}
+
+// newSchedulerStore keeps the scheduler's state where SCHEDULER_STORE says,
+// Redis unless told otherwise
+func newSchedulerStore(dependencies AdapterDependencies) scheduler.Store {
+	switch dependencies.EnvironmentVariables.SchedulerStore {
+	case configs.SchedulerStorePostgres:
+		return scheduler2.NewSchedulerStorePG(dependencies.DB)
+	case configs.SchedulerStoreMemory:
+		return scheduler2.NewMemoryStore()
+	default:
+		return scheduler2.NewSchedulerStoreRedis(dependencies.Redis)
+	}
+}
+
+// newPlanRepository keeps plans with the rest of the scheduler's state,
+// where SCHEDULER_STORE says
+func newPlanRepository(dependencies AdapterDependencies) plan.Repository {
+	switch dependencies.EnvironmentVariables.SchedulerStore {
+	case configs.SchedulerStorePostgres:
+		return plan2.NewPlanRepositoryPG(dependencies.DB)
+	case configs.SchedulerStoreMemory:
+		return plan2.NewMemoryRepository()
+	default:
+		return plan2.NewPlanRepositoryRedis(dependencies.Redis)
+	}
+}
+
+// newHealthCheckers checks what the app is running on. Memory mode needs
+// neither Postgres, Redis nor X, so they are left out of its readiness, as
+// OpenAI is when no key is set
+func newHealthCheckers(dependencies AdapterDependencies, schedulerStore scheduler.Store, memory bool) []health.Checker {
+	checkers := []health.Checker{
+		health2.NewSchedulerChecker(schedulerStore, dependencies.EnvironmentVariables.Health.HeartbeatMaxAge),
+	}
+	if dependencies.OpenAI != nil {
+		checkers = append(checkers, health2.NewOpenAIChecker(dependencies.OpenAI))
+	}
+	if !memory {
+		checkers = append(checkers,
+			health2.NewPostgresChecker(dependencies.DB),
+			health2.NewRedisChecker(dependencies.Redis),
+			xdotcom2.NewCredentialsChecker(dependencies.EnvironmentVariables),
+		)
+	}
+	return checkers
+}
+
+// newLLMRepository prompts a model through OpenAI and embeds with the
+// configured provider, through the cache when it is enabled
+func newLLMRepository(dependencies AdapterDependencies, model string, embeddingCache embedding.Embedder) llm.Repository {
+	var repository llm.Repository = openai2.NewOpenAIRepository(dependencies.OpenAI, model, dependencies.EnvironmentVariables.Embeddings)
+	if dependencies.EnvironmentVariables.Embeddings.Provider == configs.EmbeddingProviderHashing {
+		repository = hashing.NewHashingRepository(repository, dependencies.EnvironmentVariables.Embeddings)
+	}
+	if dependencies.EnvironmentVariables.EmbeddingCache.Enabled {
+		repository = cached.NewCachedRepository(repository, embeddingCache, dependencies.EnvironmentVariables.Embeddings)
+	}
+	return repository
+}
+
+// newEmbeddingCache persists embeddings to Postgres when asked to, which
+// memory mode has no database for
+func newEmbeddingCache(dependencies AdapterDependencies, cacheRepository cache.Repository, memory bool) *cached.Cache {
+	var embedder embedding.Embedder = openai2.NewEmbedder(dependencies.OpenAI)
+	if dependencies.EnvironmentVariables.Embeddings.Provider == configs.EmbeddingProviderHashing {
+		embedder = hashing.NewEmbedder()
+	}
+
+	var store embedding.CacheRepository
+	if dependencies.EnvironmentVariables.EmbeddingCache.Persist && !memory {
+		store = embedding2.NewCacheRepositoryPG(dependencies.DB)
+	}
+	return cached.NewEmbeddingCache(embedder, cacheRepository, store, dependencies.EnvironmentVariables.EmbeddingCache)
 }
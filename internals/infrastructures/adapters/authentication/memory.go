@@ -0,0 +1,105 @@
+package authentication
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/authentication"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/google/uuid"
+	"github.com/markbates/goth"
+)
+
+var userExistsErr = errors.New("user with this email already exists")
+
+// MemoryRepository keeps users in memory with the constraints of the users
+// table: emails are unique and the full name follows the first and last name
+type MemoryRepository struct {
+	mutex sync.Mutex
+	users []*authentication.User
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) CreateUser(params *authentication.AddUserParams) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	_, err := repo.add(params.Email, params.Password, params.FirstName, params.LastName, false)
+	return err
+}
+
+func (repo *MemoryRepository) GetUserDetails(params *authentication.GetUserParams) (*authentication.User, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, user := range repo.users {
+		if user.Email == params.Email || user.ID == params.ID {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, appError.NotFound(errors.New("user with does not exit"))
+}
+
+func (repo *MemoryRepository) UpdateProfile(params *authentication.UserProfileParams) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, user := range repo.users {
+		if user.ID != params.ID {
+			continue
+		}
+		if params.FirstName != "" {
+			user.FirstName = params.FirstName
+		}
+		if params.LastName != "" {
+			user.LastName = params.LastName
+		}
+		if params.EmailVerified {
+			user.EmailVerified = true
+		}
+		user.FullName = user.FirstName + " " + user.LastName
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) AddUserOAuth(user *goth.User) (*authentication.User, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	added, err := repo.add(user.Email, "", user.FirstName, user.LastName, true)
+	if err != nil {
+		return nil, err
+	}
+	created := *added
+	return &created, nil
+}
+
+func (repo *MemoryRepository) add(email, password, firstName, lastName string, verified bool) (*authentication.User, error) {
+	for _, user := range repo.users {
+		if user.Email == email {
+			return nil, appError.Conflict(userExistsErr)
+		}
+	}
+
+	now := time.Now()
+	user := &authentication.User{
+		ID:                  uuid.New(),
+		Email:               email,
+		Password:            password,
+		FirstName:           firstName,
+		LastName:            lastName,
+		FullName:            firstName + " " + lastName,
+		EmailVerified:       verified,
+		RefreshTokenVersion: 1,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	repo.users = append(repo.users, user)
+	return user, nil
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type entry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// MemoryRepository keeps keys in memory with Redis' expiry semantics. A
+// missing or expired key is redis.Nil, so callers handle it the same way
+type MemoryRepository struct {
+	mutex   sync.Mutex
+	entries map[string]entry
+	now     func() time.Time
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{entries: map[string]entry{}, now: time.Now}
+}
+
+func (repo *MemoryRepository) Set(key string, value string, expiration time.Duration) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	e := entry{value: value}
+	if expiration > 0 {
+		e.expiresAt = repo.now().Add(expiration)
+	}
+	repo.entries[key] = e
+	return nil
+}
+
+func (repo *MemoryRepository) Get(key string) (string, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	e, ok := repo.live(key)
+	if !ok {
+		return "", redis.Nil
+	}
+	return e.value, nil
+}
+
+func (repo *MemoryRepository) TTL(key string) (time.Duration, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	e, ok := repo.live(key)
+	switch {
+	case !ok:
+		return 0, errors.New("the key does not exist")
+	case e.expiresAt.IsZero():
+		return 0, errors.New("the key has no expiration")
+	}
+	return e.expiresAt.Sub(repo.now()), nil
+}
+
+// live returns an entry that has not expired, dropping it if it has
+func (repo *MemoryRepository) live(key string) (entry, bool) {
+	e, ok := repo.entries[key]
+	if ok && !e.expiresAt.IsZero() && !repo.now().Before(e.expiresAt) {
+		delete(repo.entries, key)
+		return entry{}, false
+	}
+	return e, ok
+}
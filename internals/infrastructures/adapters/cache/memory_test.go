@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewMemoryRepository()
+	repo.now = func() time.Time { return now }
+
+	assert.NoError(t, repo.Set("code", "123456", 10*time.Minute))
+	assert.NoError(t, repo.Set("forever", "1", 0))
+
+	value, err := repo.Get("code")
+	assert.NoError(t, err)
+	assert.Equal(t, "123456", value)
+	ttl, err := repo.TTL("code")
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, ttl)
+
+	_, err = repo.TTL("forever")
+	assert.EqualError(t, err, "the key has no expiration")
+
+	now = now.Add(10 * time.Minute)
+	_, err = repo.Get("code")
+	assert.ErrorIs(t, err, redis.Nil)
+	_, err = repo.TTL("code")
+	assert.EqualError(t, err, "the key does not exist")
+
+	value, err = repo.Get("forever")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", value)
+}
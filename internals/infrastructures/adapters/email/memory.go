@@ -0,0 +1,33 @@
+package email
+
+import (
+	"sync"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/email"
+)
+
+// MemoryRepository captures emails in an outbox instead of sending them
+type MemoryRepository struct {
+	mutex  sync.Mutex
+	outbox []email.MessageEmailParams
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) SendEmail(params *email.MessageEmailParams) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.outbox = append(repo.outbox, *params)
+	return nil
+}
+
+// Outbox returns every email sent so far, oldest first
+func (repo *MemoryRepository) Outbox() []email.MessageEmailParams {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return append([]email.MessageEmailParams(nil), repo.outbox...)
+}
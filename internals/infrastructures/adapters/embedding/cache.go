@@ -0,0 +1,66 @@
+package embedding
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/lib/pq"
+)
+
+type CacheRepositoryPG struct {
+	db *sql.DB
+}
+
+func NewCacheRepositoryPG(db *sql.DB) embedding.CacheRepository {
+	return &CacheRepositoryPG{db: db}
+}
+
+func (repo *CacheRepositoryPG) GetCached(keys []string) (map[string][]float32, error) {
+	query, args, err := sq.Select("key", "embedding").
+		From("embedding_cache").
+		Where(sq.Eq{"key": keys}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	embeddings := make(map[string][]float32, len(keys))
+	for rows.Next() {
+		var (
+			key    string
+			vector []float32
+		)
+		if err = rows.Scan(&key, (*pq.Float32Array)(&vector)); err != nil {
+			return nil, err
+		}
+		embeddings[key] = vector
+	}
+	return embeddings, rows.Err()
+}
+
+func (repo *CacheRepositoryPG) AddCached(model string, embeddings map[string][]float32) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+
+	builder := sq.Insert("embedding_cache").Columns("key", "model", "embedding")
+	for key, vector := range embeddings {
+		builder = builder.Values(key, model, pq.Array(vector))
+	}
+	query, args, err := builder.
+		Suffix("ON CONFLICT (key) DO NOTHING").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.Exec(query, args...)
+	return err
+}
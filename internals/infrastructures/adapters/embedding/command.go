@@ -2,35 +2,72 @@ package embedding
 
 import (
 	"database/sql"
+	"errors"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
 	"github.com/pgvector/pgvector-go"
 )
 
+var embeddingNotFoundErr = errors.New("embedding not found")
+
 type Repository struct {
-	db *sql.DB
+	db         *sql.DB
+	config     *configs.VectorSearch
+	embeddings *configs.Embeddings
 }
 
-func NewEmbedding(db *sql.DB) embedding.Repository {
-	return &Repository{db: db}
+func NewEmbedding(db *sql.DB, config *configs.VectorSearch, embeddings *configs.Embeddings) embedding.Repository {
+	return &Repository{db: db, config: config, embeddings: embeddings}
 }
 
-func (repo *Repository) AddEmbedding(embedding []float32, value string) error {
+// Runner is what embeddings are read and written through, so repositories
+// that keep their own rows next to an embedding can do it in a transaction
+type Runner interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func (repo *Repository) AddEmbedding(embedding []float32, value, contentType string, postID *int) (int, error) {
+	model, _ := repo.embeddings.Active()
+	return Insert(repo.db, model, embedding, value, contentType, postID)
+}
+
+// Insert stores an embedding made with a model as a half precision vector and returns its id
+func Insert(runner Runner, model string, embedding []float32, value, contentType string, postID *int) (int, error) {
 	query, args, err := sq.Insert("embeddings").
-		Columns("topic", "embedding").
-		Values(value, pgvector.NewVector(embedding)).
+		Columns("topic", "content_type", "model", "dimensions", "embedding", "post_id").
+		Values(value, contentType, model, len(embedding), pgvector.NewHalfVector(embedding), postID).
+		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	var statement *sql.Stmt
-	statement, err = repo.db.Prepare(query)
+	var id int
+	err = runner.QueryRow(query, args...).Scan(&id)
+	return id, err
+}
+
+func (repo *Repository) DeleteEmbedding(id int) error {
+	query, args, err := sq.Delete("embeddings").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return err
 	}
-	defer statement.Close()
 
-	_, err = statement.Exec(args...)
-	return err
+	result, err := repo.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return appError.NotFound(embeddingNotFoundErr)
+	}
+	return nil
 }
@@ -0,0 +1,332 @@
+package embedding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type storedEmbedding struct {
+	id          int
+	value       string
+	contentType string
+	model       string
+	vector      []float32
+	postID      *int
+	createdAt   time.Time
+}
+
+// Passages is the knowledge kept in memory, which migrations re-embed
+// along with the stored values
+type Passages interface {
+	PendingPassages() []embedding.Pending
+	SwapEmbeddings(model string, vectors map[int][]float32)
+}
+
+// MemoryRepository keeps embeddings in memory and compares them by brute
+// force under the configured metric, so flows run without pgvector. There
+// are no posts to link search results to, so results only carry a post ID
+type MemoryRepository struct {
+	mutex          sync.Mutex
+	nextID         int
+	embeddings     []*storedEmbedding
+	migrations     []*embedding.Migration
+	shadow         map[int][]float32
+	shadowPassages map[int][]float32
+	passages       Passages
+	config         *configs.VectorSearch
+	models         *configs.Embeddings
+}
+
+// NewMemoryRepository keeps embeddings in memory. passages may be nil,
+// which leaves migrations to the stored values alone
+func NewMemoryRepository(config *configs.VectorSearch, embeddings *configs.Embeddings, passages Passages) *MemoryRepository {
+	return &MemoryRepository{config: config, models: embeddings, passages: passages, shadow: map[int][]float32{}, shadowPassages: map[int][]float32{}}
+}
+
+func (repo *MemoryRepository) AddEmbedding(vector []float32, value, contentType string, postID *int) (int, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	model, _ := repo.models.Active()
+	repo.nextID++
+	repo.embeddings = append(repo.embeddings, &storedEmbedding{
+		id:          repo.nextID,
+		value:       value,
+		contentType: contentType,
+		model:       model,
+		vector:      vector,
+		postID:      postID,
+		createdAt:   time.Now(),
+	})
+	return repo.nextID, nil
+}
+
+func (repo *MemoryRepository) Nearest(vector []float32, contentType string) (*embedding.Match, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	matches, err := repo.ranked(vector, func(e *storedEmbedding) bool {
+		return e.contentType == contentType && (repo.config.Lookback <= 0 || !e.createdAt.Before(time.Now().Add(-repo.config.Lookback)))
+	})
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	return &matches[0].Match, nil
+}
+
+func (repo *MemoryRepository) Recent(contentType string, limit int) ([]embedding.Match, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	matches := make([]embedding.Match, 0, limit)
+	for i := len(repo.embeddings) - 1; i >= 0 && len(matches) < limit; i-- {
+		if e := repo.embeddings[i]; e.contentType == contentType {
+			matches = append(matches, embedding.Match{ID: e.id, Value: e.value, ContentType: e.contentType, CreatedAt: e.createdAt})
+		}
+	}
+	return matches, nil
+}
+
+func (repo *MemoryRepository) Search(vector []float32, contentType string, limit int) ([]embedding.Result, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	results, err := repo.ranked(vector, func(e *storedEmbedding) bool {
+		return contentType == "" || e.contentType == contentType
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results[:min(limit, len(results))], nil
+}
+
+func (repo *MemoryRepository) DeleteEmbedding(id int) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for i, e := range repo.embeddings {
+		if e.id == id {
+			repo.embeddings = append(repo.embeddings[:i], repo.embeddings[i+1:]...)
+			delete(repo.shadow, id)
+			return nil
+		}
+	}
+	return appError.NotFound(embeddingNotFoundErr)
+}
+
+// ranked scores the embeddings of the configured model that pass a filter
+// and returns them closest first
+func (repo *MemoryRepository) ranked(vector []float32, filter func(*storedEmbedding) bool) ([]embedding.Result, error) {
+	if _, ok := operators[repo.config.Metric]; !ok {
+		return nil, fmt.Errorf("unknown vector search metric %q", repo.config.Metric)
+	}
+
+	model, _ := repo.models.Active()
+	var results []embedding.Result
+	for _, e := range repo.embeddings {
+		if e.model != model || !filter(e) {
+			continue
+		}
+		if len(e.vector) != len(vector) {
+			return nil, fmt.Errorf("different vector dimensions %d and %d", len(e.vector), len(vector))
+		}
+		score := Score(repo.config.Metric, distance(repo.config.Metric, e.vector, vector))
+		results = append(results, embedding.Result{
+			Match: embedding.Match{
+				ID:          e.id,
+				Value:       e.value,
+				ContentType: e.contentType,
+				Score:       score,
+				Similar:     embedding.IsSimilar(repo.config.Metric, score, Threshold(repo.config, e.contentType)),
+				CreatedAt:   e.createdAt,
+			},
+			PostID: e.postID,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if repo.config.Metric == embedding.MetricL2 {
+			return results[i].Score < results[j].Score
+		}
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// distance is what the metric's pgvector operator returns
+func distance(metric string, a, b []float32) float64 {
+	var dot, normA, normB, squared float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		normA += x * x
+		normB += y * y
+		squared += (x - y) * (x - y)
+	}
+
+	switch metric {
+	case embedding.MetricL2:
+		return math.Sqrt(squared)
+	case embedding.MetricInnerProduct:
+		return -dot
+	default:
+		if normA == 0 || normB == 0 {
+			return math.NaN()
+		}
+		return 1 - dot/math.Sqrt(normA*normB)
+	}
+}
+
+func (repo *MemoryRepository) StartMigration(params *embedding.Migration) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.running() != nil {
+		return appError.Conflict(errors.New("another embedding migration is running"))
+	}
+	params.ID = len(repo.migrations) + 1
+	params.Status = embedding.MigrationRunning
+	params.CreatedAt = time.Now()
+	migration := *params
+	repo.migrations = append(repo.migrations, &migration)
+	repo.shadow, repo.shadowPassages = map[int][]float32{}, map[int][]float32{}
+	return nil
+}
+
+func (repo *MemoryRepository) GetMigration() (*embedding.Migration, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	running := repo.running()
+	if running == nil {
+		return nil, nil
+	}
+	migration := *running
+	migration.Total = len(repo.embeddings) + len(repo.pendingPassages())
+	migration.Done = len(repo.shadow) + len(repo.shadowPassages)
+	return &migration, nil
+}
+
+func (repo *MemoryRepository) PendingEmbeddings(limit int) ([]embedding.Pending, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.running() == nil {
+		return nil, nil
+	}
+	var pending []embedding.Pending
+	for _, e := range repo.embeddings {
+		if _, ok := repo.shadow[e.id]; !ok && len(pending) < limit {
+			pending = append(pending, embedding.Pending{ID: e.id, Value: e.value})
+		}
+	}
+	for _, p := range repo.pendingPassages() {
+		if _, ok := repo.shadowPassages[p.ID]; !ok && len(pending) < limit {
+			pending = append(pending, p)
+		}
+	}
+	return pending, nil
+}
+
+func (repo *MemoryRepository) AddShadowEmbeddings(migrationID int, embeddings, passages map[int][]float32) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for id, vector := range embeddings {
+		if _, ok := repo.shadow[id]; !ok && repo.find(id) != nil {
+			repo.shadow[id] = vector
+		}
+	}
+	for _, p := range repo.pendingPassages() {
+		if _, ok := repo.shadowPassages[p.ID]; !ok && passages[p.ID] != nil {
+			repo.shadowPassages[p.ID] = passages[p.ID]
+		}
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) CutOver(migration *embedding.Migration) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	pending := len(repo.embeddings) + len(repo.pendingPassages()) - len(repo.shadow) - len(repo.shadowPassages)
+	if pending > 0 {
+		return appError.Conflict(fmt.Errorf("%d embeddings are still to be re-embedded", pending))
+	}
+	for _, e := range repo.embeddings {
+		e.vector, e.model = repo.shadow[e.id], migration.Model
+	}
+	if repo.passages != nil {
+		repo.passages.SwapEmbeddings(migration.Model, repo.shadowPassages)
+	}
+	return repo.finish(migration, embedding.MigrationCompleted)
+}
+
+func (repo *MemoryRepository) CancelMigration(migration *embedding.Migration) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return repo.finish(migration, embedding.MigrationCancelled)
+}
+
+func (repo *MemoryRepository) ActiveModel() (*embedding.Migration, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var active *embedding.Migration
+	for _, migration := range repo.migrations {
+		if migration.Status == embedding.MigrationCompleted && (active == nil || migration.CompletedAt.After(*active.CompletedAt)) {
+			active = migration
+		}
+	}
+	if active == nil {
+		return nil, nil
+	}
+	migration := *active
+	return &migration, nil
+}
+
+func (repo *MemoryRepository) finish(migration *embedding.Migration, status string) error {
+	running := repo.running()
+	if running == nil || running.ID != migration.ID {
+		return appError.NotFound(errors.New("no embedding migration is running"))
+	}
+	now := time.Now()
+	running.Status, running.CompletedAt = status, &now
+	migration.Status, migration.CompletedAt = status, &now
+	repo.shadow, repo.shadowPassages = map[int][]float32{}, map[int][]float32{}
+	return nil
+}
+
+func (repo *MemoryRepository) pendingPassages() []embedding.Pending {
+	if repo.passages == nil {
+		return nil
+	}
+	return repo.passages.PendingPassages()
+}
+
+func (repo *MemoryRepository) running() *embedding.Migration {
+	for _, migration := range repo.migrations {
+		if migration.Status == embedding.MigrationRunning {
+			return migration
+		}
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) find(id int) *storedEmbedding {
+	for _, e := range repo.embeddings {
+		if e.id == id {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package embedding
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	knowledge2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/knowledge"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_Nearest(t *testing.T) {
+	repo := NewMemoryRepository(
+		&configs.VectorSearch{Metric: embedding.MetricCosine, Threshold: 0.9},
+		&configs.Embeddings{Model: "small"},
+		nil,
+	)
+
+	match, err := repo.Nearest([]float32{1, 0}, embedding.ContentTopic)
+	assert.NoError(t, err)
+	assert.Nil(t, match)
+
+	_, _ = repo.AddEmbedding([]float32{0, 1}, "OpenGov", embedding.ContentTopic, nil)
+	id, _ := repo.AddEmbedding([]float32{1, 0.1}, "XCM fees", embedding.ContentTopic, nil)
+	postID := 7
+	_, _ = repo.AddEmbedding([]float32{1, 0}, "XCM fees are paid in DOT", embedding.ContentTweet, &postID)
+
+	match, err = repo.Nearest([]float32{1, 0}, embedding.ContentTopic)
+	assert.NoError(t, err)
+	assert.Equal(t, id, match.ID)
+	assert.True(t, match.Similar)
+
+	results, err := repo.Search([]float32{1, 0}, "", 2)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "XCM fees are paid in DOT", results[0].Value)
+		assert.Equal(t, &postID, results[0].PostID)
+		assert.InDelta(t, 1, results[0].Score, 0.0001)
+		assert.Equal(t, "XCM fees", results[1].Value)
+	}
+
+	assert.NoError(t, repo.DeleteEmbedding(id))
+	match, _ = repo.Nearest([]float32{1, 0}, embedding.ContentTopic)
+	assert.Equal(t, "OpenGov", match.Value)
+	assert.False(t, match.Similar)
+	assert.Error(t, repo.DeleteEmbedding(id))
+}
+
+func TestMemoryRepository_Migration(t *testing.T) {
+	models := &configs.Embeddings{Model: "small"}
+	repo := NewMemoryRepository(&configs.VectorSearch{Metric: embedding.MetricL2, Threshold: 0.5}, models, nil)
+	first, _ := repo.AddEmbedding([]float32{1, 0}, "XCM fees", embedding.ContentTopic, nil)
+	second, _ := repo.AddEmbedding([]float32{0, 1}, "OpenGov", embedding.ContentTopic, nil)
+
+	migration := &embedding.Migration{Model: "large", Dimensions: 3}
+	assert.NoError(t, repo.StartMigration(migration))
+	assert.Error(t, repo.StartMigration(&embedding.Migration{Model: "other", Dimensions: 3}))
+
+	assert.NoError(t, repo.AddShadowEmbeddings(migration.ID, map[int][]float32{first: {1, 0, 0}}, nil))
+	pending, _ := repo.PendingEmbeddings(10)
+	assert.Equal(t, []embedding.Pending{{ID: second, Value: "OpenGov"}}, pending)
+	assert.Error(t, repo.CutOver(migration))
+
+	assert.NoError(t, repo.AddShadowEmbeddings(migration.ID, map[int][]float32{second: {0, 1, 0}}, nil))
+	running, _ := repo.GetMigration()
+	assert.Equal(t, 2, running.Done)
+	assert.NoError(t, repo.CutOver(migration))
+	assert.Equal(t, embedding.MigrationCompleted, migration.Status)
+
+	running, _ = repo.GetMigration()
+	assert.Nil(t, running)
+	active, _ := repo.ActiveModel()
+	assert.Equal(t, "large", active.Model)
+
+	models.Use("large", 3)
+	match, err := repo.Nearest([]float32{0, 1, 0}, embedding.ContentTopic)
+	assert.NoError(t, err)
+	assert.Equal(t, second, match.ID)
+	assert.InDelta(t, 0, match.Score, 0.0001)
+	assert.True(t, match.Similar)
+}
+
+func TestMemoryRepository_Migration_passages(t *testing.T) {
+	models := &configs.Embeddings{Model: "small"}
+	passages := knowledge2.NewMemoryRepository(models)
+	_ = passages.AddPassage(&knowledge.AddPassageParams{Source: "wiki", Content: "DOT pays XCM fees"}, []float32{1, 0})
+	repo := NewMemoryRepository(&configs.VectorSearch{Metric: embedding.MetricL2, Threshold: 0.5}, models, passages)
+	id, _ := repo.AddEmbedding([]float32{1, 0}, "XCM fees", embedding.ContentTopic, nil)
+
+	migration := &embedding.Migration{Model: "large", Dimensions: 3}
+	assert.NoError(t, repo.StartMigration(migration))
+	assert.NoError(t, repo.AddShadowEmbeddings(migration.ID, map[int][]float32{id: {1, 0, 0}}, nil))
+	pending, _ := repo.PendingEmbeddings(10)
+	assert.Equal(t, []embedding.Pending{{ID: 1, Value: "DOT pays XCM fees", Passage: true}}, pending)
+	assert.Error(t, repo.CutOver(migration))
+
+	assert.NoError(t, repo.AddShadowEmbeddings(migration.ID, nil, map[int][]float32{1: {0, 1, 0}}))
+	running, _ := repo.GetMigration()
+	assert.Equal(t, 2, running.Total)
+	assert.Equal(t, 2, running.Done)
+	assert.NoError(t, repo.CutOver(migration))
+
+	models.Use("large", 3)
+	nearest, err := passages.NearestPassages([]float32{0, 1, 0}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, nearest, 1) {
+		assert.Equal(t, "DOT pays XCM fees", nearest[0].Content)
+	}
+}
+
+func TestMemoryRepository_Nearest_model(t *testing.T) {
+	models := &configs.Embeddings{Model: "small"}
+	repo := NewMemoryRepository(&configs.VectorSearch{Metric: embedding.MetricCosine, Threshold: 0.9}, models, nil)
+	_, _ = repo.AddEmbedding([]float32{1, 0}, "XCM fees", embedding.ContentTopic, nil)
+
+	models.Use("large", 2)
+	match, err := repo.Nearest([]float32{1, 0}, embedding.ContentTopic)
+	assert.NoError(t, err)
+	assert.Nil(t, match)
+}
@@ -0,0 +1,321 @@
+package embedding
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/pgvector/pgvector-go"
+)
+
+// operatorClasses are the HNSW operator classes that serve every metric
+var operatorClasses = map[string]string{
+	embedding.MetricCosine:       "halfvec_cosine_ops",
+	embedding.MetricL2:           "halfvec_l2_ops",
+	embedding.MetricInnerProduct: "halfvec_ip_ops",
+}
+
+var migrationColumns = []string{"id", "model", "dimensions", "status", "created_at", "completed_at"}
+
+func (repo *Repository) StartMigration(params *embedding.Migration) error {
+	query, args, err := sq.Insert("embedding_migrations").
+		Columns("model", "dimensions", "status").
+		Values(params.Model, params.Dimensions, embedding.MigrationRunning).
+		Suffix("ON CONFLICT DO NOTHING RETURNING id, status, created_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = repo.db.QueryRow(query, args...).Scan(&params.ID, &params.Status, &params.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return appError.Conflict(errors.New("another embedding migration is running"))
+	}
+	return err
+}
+
+func (repo *Repository) GetMigration() (*embedding.Migration, error) {
+	query, args, err := sq.Select(migrationColumns...).From("embedding_migrations").
+		Where(sq.Eq{"status": embedding.MigrationRunning}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var m embedding.Migration
+	err = repo.db.QueryRow(query, args...).Scan(&m.ID, &m.Model, &m.Dimensions, &m.Status, &m.CreatedAt, &m.CompletedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	for _, table := range shadowTables {
+		total, done, err := progress(repo.db, table, m.ID)
+		if err != nil {
+			return nil, err
+		}
+		m.Total, m.Done = m.Total+total, m.Done+done
+	}
+	return &m, nil
+}
+
+// shadowTable is a table of embedded values and the table its shadow
+// embeddings are made in while a migration runs
+type shadowTable struct {
+	name     string
+	value    string
+	shadow   string
+	key      string
+	passages bool
+}
+
+var shadowTables = []shadowTable{
+	{name: "embeddings", value: "topic", shadow: "embeddings_next", key: "embedding_id"},
+	{name: "knowledge_passages", value: "content", shadow: "knowledge_passages_next", key: "passage_id", passages: true},
+}
+
+// progress counts the rows of a table and how many of them a migration has re-embedded
+func progress(runner Runner, table shadowTable, migrationID int) (int, int, error) {
+	query, args, err := sq.Select("COUNT(*)", "COUNT(n."+table.key+")").
+		From(table.name+" e").
+		LeftJoin(table.shadow+" n ON n."+table.key+" = e.id AND n.migration_id = ?", migrationID).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+	var total, done int
+	err = runner.QueryRow(query, args...).Scan(&total, &done)
+	return total, done, err
+}
+
+func (repo *Repository) PendingEmbeddings(limit int) ([]embedding.Pending, error) {
+	var pending []embedding.Pending
+	for _, table := range shadowTables {
+		if len(pending) >= limit {
+			break
+		}
+		// Values added while the migration runs are picked up by a later batch
+		query, args, err := sq.Select("e.id", "e."+table.value).
+			From(table.name+" e").
+			Join("embedding_migrations m ON m.status = ?", embedding.MigrationRunning).
+			LeftJoin(table.shadow + " n ON n." + table.key + " = e.id AND n.migration_id = m.id").
+			Where(sq.Eq{"n." + table.key: nil}).
+			OrderBy("e.id ASC").
+			Limit(uint64(limit - len(pending))).
+			PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := repo.db.Query(query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			p := embedding.Pending{Passage: table.passages}
+			if err = rows.Scan(&p.ID, &p.Value); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			pending = append(pending, p)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+func (repo *Repository) AddShadowEmbeddings(migrationID int, embeddings, passages map[int][]float32) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// A value deleted since it was read has nothing to shadow, and one that
+	// an interrupted batch already re-embedded keeps its vector
+	for id, vector := range embeddings {
+		query, args, err := sq.Insert("embeddings_next").
+			Columns("embedding_id", "migration_id", "embedding").
+			Select(sq.Select("id").
+				Column("?::integer", migrationID).
+				Column("?::halfvec", pgvector.NewHalfVector(vector)).
+				From("embeddings").
+				Where(sq.Eq{"id": id})).
+			Suffix("ON CONFLICT (embedding_id) DO NOTHING").
+			PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+	for id, vector := range passages {
+		query, args, err := sq.Insert("knowledge_passages_next").
+			Columns("passage_id", "migration_id", "embedding").
+			Select(sq.Select("id").
+				Column("?::integer", migrationID).
+				Column("?::vector", pgvector.NewVector(vector)).
+				From("knowledge_passages").
+				Where(sq.Eq{"id": id})).
+			Suffix("ON CONFLICT (passage_id) DO NOTHING").
+			PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *Repository) CutOver(migration *embedding.Migration) error {
+	operatorClass, ok := operatorClasses[repo.config.Metric]
+	if !ok {
+		return fmt.Errorf("unknown vector search metric %q", repo.config.Metric)
+	}
+
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Nothing can be added between the last check and the swap
+	if _, err = tx.Exec("LOCK TABLE embeddings, knowledge_passages IN ACCESS EXCLUSIVE MODE"); err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, table := range shadowTables {
+		total, done, err := progress(tx, table, migration.ID)
+		if err != nil {
+			return err
+		}
+		pending += total - done
+	}
+	if pending > 0 {
+		return appError.Conflict(fmt.Errorf("%d embeddings are still to be re-embedded", pending))
+	}
+
+	// The column takes the new dimension, so the index is rebuilt for it
+	statements := []string{
+		"DROP INDEX IF EXISTS embeddings_embedding_cosine_idx",
+		"DROP INDEX IF EXISTS embeddings_embedding_l2_idx",
+		"DROP INDEX IF EXISTS embeddings_embedding_inner_product_idx",
+		fmt.Sprintf("ALTER TABLE embeddings ALTER COLUMN embedding TYPE halfvec(%d) USING NULL", migration.Dimensions),
+	}
+	for _, statement := range statements {
+		if _, err = tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Update("embeddings e").
+		Set("embedding", sq.Expr("n.embedding")).
+		Set("model", migration.Model).
+		Set("dimensions", migration.Dimensions).
+		From("embeddings_next n").
+		Where("n.embedding_id = e.id AND n.migration_id = ?", migration.ID).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = sq.Update("knowledge_passages e").
+		Set("embedding", sq.Expr("n.embedding")).
+		Set("model", migration.Model).
+		Set("dimensions", migration.Dimensions).
+		From("knowledge_passages_next n").
+		Where("n.passage_id = e.id AND n.migration_id = ?", migration.ID).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	statement := fmt.Sprintf("CREATE INDEX embeddings_embedding_%s_idx ON embeddings USING hnsw (embedding %s)", repo.config.Metric, operatorClass)
+	if _, err = tx.Exec(statement); err != nil {
+		return err
+	}
+
+	if err = finishMigration(tx, migration, embedding.MigrationCompleted); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *Repository) CancelMigration(migration *embedding.Migration) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = finishMigration(tx, migration, embedding.MigrationCancelled); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *Repository) ActiveModel() (*embedding.Migration, error) {
+	query, args, err := sq.Select(migrationColumns...).From("embedding_migrations").
+		Where(sq.Eq{"status": embedding.MigrationCompleted}).
+		OrderBy("completed_at DESC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var m embedding.Migration
+	err = repo.db.QueryRow(query, args...).Scan(&m.ID, &m.Model, &m.Dimensions, &m.Status, &m.CreatedAt, &m.CompletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// finishMigration ends a migration and drops its shadow embeddings
+func finishMigration(tx *sql.Tx, migration *embedding.Migration, status string) error {
+	for _, table := range shadowTables {
+		query, args, err := sq.Delete(table.shadow).
+			Where(sq.Eq{"migration_id": migration.ID}).
+			PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Update("embedding_migrations").
+		Set("status", status).
+		Set("completed_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": migration.ID}).
+		Suffix("RETURNING status, completed_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return tx.QueryRow(query, args...).Scan(&migration.Status, &migration.CompletedAt)
+}
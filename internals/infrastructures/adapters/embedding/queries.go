@@ -4,38 +4,181 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/lib/pq"
 	"github.com/pgvector/pgvector-go"
 )
 
-func (repo *Repository) SimilarValuesExist(embedding []float32) (*bool, error) {
-	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+// operators are the pgvector distance operators of every metric. Ordering
+// by them is what lets the HNSW index built for the metric be used
+var operators = map[string]string{
+	embedding.MetricCosine:       "<=>",
+	embedding.MetricL2:           "<->",
+	embedding.MetricInnerProduct: "<#>",
+}
 
-	// Build query using proper parameter placeholders
-	query, _, err := psql.Select("topic", "embedding <#> $1 as similarity").
-		From("embeddings").
-		Where("embedding <#> $1 < $2"). // Cosine similarity check
-		OrderBy("embedding <#> $1").    // Order by similarity
-		Limit(1).
-		ToSql()
+func (repo *Repository) Nearest(embedding []float32, contentType string) (*embedding.Match, error) {
+	model, _ := repo.embeddings.Active()
+	return Nearest(repo.db, repo.config, model, embedding, contentType)
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to build query: %w", err)
+// Nearest returns the stored value of a content type nearest to an
+// embedding made with a model, or nil when nothing was stored within the
+// lookback window. Values embedded with other models are never compared
+func Nearest(runner Runner, config *configs.VectorSearch, model string, vector []float32, contentType string) (*embedding.Match, error) {
+	operator, ok := operators[config.Metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown vector search metric %q", config.Metric)
 	}
 
-	// Use QueryRow directly with the generated query and args
-	var topic string
-	var similarity float64
+	halfVector := pgvector.NewHalfVector(vector)
+	distance := "embedding " + operator + " ?::halfvec"
+	builder := sq.Select("id", "topic", "content_type").
+		Column(sq.Expr(distance, halfVector)).
+		Column("created_at").
+		From("embeddings").
+		Where(sq.Eq{"content_type": contentType, "model": model}).
+		OrderByClause(distance, halfVector).
+		Limit(1)
+	if config.Lookback > 0 {
+		builder = builder.Where(sq.GtOrEq{"created_at": time.Now().Add(-config.Lookback)})
+	}
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
 
-	err = repo.db.QueryRow(query, pgvector.NewVector(embedding), -0.7).Scan(&topic, &similarity)
+	var match embedding.Match
+	err = runner.QueryRow(query, args...).Scan(&match.ID, &match.Value, &match.ContentType, &match.Score, &match.CreatedAt)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
-		f := false
-		return &f, nil
-	case err == nil:
-		t := true
-		return &t, nil
-	default:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+
+	match.Score = Score(config.Metric, match.Score)
+	match.Similar = embedding.IsSimilar(config.Metric, match.Score, Threshold(config, contentType))
+	return &match, nil
+}
+
+func (repo *Repository) Recent(contentType string, limit int) ([]embedding.Match, error) {
+	query, args, err := sq.Select("id", "topic", "content_type", "created_at").
+		From("embeddings").
+		Where(sq.Eq{"content_type": contentType}).
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	matches := make([]embedding.Match, 0, limit)
+	for rows.Next() {
+		var match embedding.Match
+		if err = rows.Scan(&match.ID, &match.Value, &match.ContentType, &match.CreatedAt); err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// latestPost joins the post a value went out in. A tweet knows its post and
+// a topic is matched to the latest post made from it
+const latestPost = `LATERAL (
+	SELECT id, posted_at, tweet_ids FROM posts
+	WHERE posts.id = e.post_id OR (e.post_id IS NULL AND posts.topic = e.topic)
+	ORDER BY posted_at DESC LIMIT 1
+) p ON true`
+
+func (repo *Repository) Search(vector []float32, contentType string, limit int) ([]embedding.Result, error) {
+	operator, ok := operators[repo.config.Metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown vector search metric %q", repo.config.Metric)
+	}
+
+	model, _ := repo.embeddings.Active()
+	halfVector := pgvector.NewHalfVector(vector)
+	distance := "e.embedding " + operator + " ?::halfvec"
+	builder := sq.Select("e.id", "e.topic", "e.content_type").
+		Column(sq.Expr(distance, halfVector)).
+		Columns("e.created_at", "p.id", "p.posted_at", "p.tweet_ids").
+		From("embeddings e").
+		LeftJoin(latestPost).
+		Where(sq.Eq{"e.model": model}).
+		OrderByClause(distance, halfVector).
+		Limit(uint64(limit))
+	if contentType != "" {
+		builder = builder.Where(sq.Eq{"e.content_type": contentType})
+	}
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
 	}
+	defer rows.Close()
+
+	results := make([]embedding.Result, 0, limit)
+	for rows.Next() {
+		var (
+			result   embedding.Result
+			postID   sql.NullInt64
+			postedAt sql.NullTime
+			tweetIDs []string
+		)
+		err = rows.Scan(&result.ID, &result.Value, &result.ContentType, &result.Score, &result.CreatedAt, &postID, &postedAt, pq.Array(&tweetIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		result.Score = Score(repo.config.Metric, result.Score)
+		result.Similar = embedding.IsSimilar(repo.config.Metric, result.Score, Threshold(repo.config, result.ContentType))
+		if postID.Valid {
+			id := int(postID.Int64)
+			result.PostID = &id
+			result.PostedAt = &postedAt.Time
+			for _, tweetID := range tweetIDs {
+				result.TweetURLs = append(result.TweetURLs, xdotcom.StatusURL(tweetID))
+			}
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// Score turns a pgvector distance into the metric's score. Cosine distance
+// is one minus the similarity and <#> is the negative inner product
+func Score(metric string, distance float64) float64 {
+	switch metric {
+	case embedding.MetricCosine:
+		return 1 - distance
+	case embedding.MetricInnerProduct:
+		return -distance
+	default:
+		return distance
+	}
+}
+
+// Threshold is the score a content type's values count as the same at
+func Threshold(config *configs.VectorSearch, contentType string) float64 {
+	if threshold, ok := config.Thresholds[contentType]; ok {
+		return threshold
+	}
+	return config.Threshold
 }
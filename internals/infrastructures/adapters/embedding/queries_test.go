@@ -0,0 +1,35 @@
+package embedding
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	config := &configs.VectorSearch{Threshold: 0.8, Thresholds: map[string]float64{embedding.ContentTopic: 0.7}}
+
+	tests := []struct {
+		name        string
+		metric      string
+		distance    float64
+		contentType string
+		wantScore   float64
+		wantSimilar bool
+	}{
+		{"cosine within threshold", embedding.MetricCosine, 0.25, embedding.ContentTopic, 0.75, true},
+		{"cosine outside threshold", embedding.MetricCosine, 0.35, embedding.ContentTopic, 0.65, false},
+		{"inner product uses the default threshold", embedding.MetricInnerProduct, -0.75, "tweet", 0.75, false},
+		{"l2 lower is closer", embedding.MetricL2, 0.5, embedding.ContentTopic, 0.5, true},
+		{"l2 too far", embedding.MetricL2, 0.9, embedding.ContentTopic, 0.9, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := Score(tt.metric, tt.distance)
+			assert.InDelta(t, tt.wantScore, score, 1e-9)
+			assert.Equal(t, tt.wantSimilar, embedding.IsSimilar(tt.metric, score, Threshold(config, tt.contentType)))
+		})
+	}
+}
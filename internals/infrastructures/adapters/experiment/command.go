@@ -0,0 +1,76 @@
+package experiment
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+)
+
+type RepositoryPG struct {
+	db *sql.DB
+}
+
+func NewExperimentRepositoryPG(db *sql.DB) experiment.Repository {
+	return &RepositoryPG{db: db}
+}
+
+func (repo *RepositoryPG) AddExperiment(params *experiment.Experiment) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Insert("experiments").
+		Columns("name", "template", "status").
+		Values(params.Name, params.Template, params.Status).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if err = tx.QueryRow(query, args...).Scan(&params.ID, &params.CreatedAt); err != nil {
+		return err
+	}
+
+	for i := range params.Variants {
+		variant := &params.Variants[i]
+		variant.ExperimentID = params.ID
+
+		query, args, err = sq.Insert("experiment_variants").
+			Columns("experiment_id", "name", "body", "weight").
+			Values(variant.ExperimentID, variant.Name, variant.Body, variant.Weight).
+			Suffix("RETURNING id").
+			PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return err
+		}
+		if err = tx.QueryRow(query, args...).Scan(&variant.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *RepositoryPG) UpdateExperiment(params *experiment.Experiment) error {
+	query, args, err := sq.Update("experiments").SetMap(map[string]interface{}{
+		"status":            params.Status,
+		"winner_variant_id": params.WinnerVariantID,
+		"stopped_at":        params.StoppedAt,
+	}).Where(sq.Eq{"id": params.ID}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
@@ -0,0 +1,102 @@
+package experiment
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	post2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/post"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// MemoryRepository keeps experiments in memory. Variant metrics come from
+// the posts kept in memory, as they come from the posts table in Postgres
+type MemoryRepository struct {
+	mutex         sync.Mutex
+	nextID        int
+	nextVariantID int
+	experiments   []*experiment.Experiment
+	posts         *post2.MemoryRepository
+}
+
+func NewMemoryRepository(posts *post2.MemoryRepository) *MemoryRepository {
+	return &MemoryRepository{posts: posts}
+}
+
+func (repo *MemoryRepository) AddExperiment(params *experiment.Experiment) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.nextID++
+	params.ID = repo.nextID
+	params.CreatedAt = time.Now()
+	for i := range params.Variants {
+		repo.nextVariantID++
+		params.Variants[i].ID = repo.nextVariantID
+		params.Variants[i].ExperimentID = params.ID
+	}
+	repo.experiments = append(repo.experiments, clone(params))
+	return nil
+}
+
+func (repo *MemoryRepository) GetExperiment(id int) (*experiment.Experiment, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, e := range repo.experiments {
+		if e.ID == id {
+			return clone(e), nil
+		}
+	}
+	return nil, appError.NotFound(errors.New("experiment does not exist"))
+}
+
+func (repo *MemoryRepository) GetExperiments() ([]experiment.Experiment, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	experiments := make([]experiment.Experiment, 0, len(repo.experiments))
+	for _, e := range repo.experiments {
+		experiments = append(experiments, *clone(e))
+	}
+	sort.SliceStable(experiments, func(i, j int) bool { return experiments[i].CreatedAt.After(experiments[j].CreatedAt) })
+	return experiments, nil
+}
+
+func (repo *MemoryRepository) GetActiveExperiment(template string) (*experiment.Experiment, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for i := len(repo.experiments) - 1; i >= 0; i-- {
+		e := repo.experiments[i]
+		if e.Template == template && (e.Status == experiment.StatusRunning || e.Status == experiment.StatusPromoted) {
+			return clone(e), nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *MemoryRepository) UpdateExperiment(params *experiment.Experiment) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, e := range repo.experiments {
+		if e.ID == params.ID {
+			e.Status, e.WinnerVariantID, e.StoppedAt = params.Status, params.WinnerVariantID, params.StoppedAt
+		}
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) GetVariantMetrics(experimentID int) ([]experiment.VariantMetrics, error) {
+	return repo.posts.VariantMetrics(experimentID), nil
+}
+
+// clone copies an experiment with its variants, so callers never share them
+func clone(e *experiment.Experiment) *experiment.Experiment {
+	copied := *e
+	copied.Variants = append([]experiment.Variant(nil), e.Variants...)
+	return &copied
+}
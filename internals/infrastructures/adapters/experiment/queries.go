@@ -0,0 +1,178 @@
+package experiment
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+var experimentColumns = []string{
+	"id", "name", "template", "status", "winner_variant_id", "created_at", "stopped_at",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanExperiment(row scanner) (*experiment.Experiment, error) {
+	var e experiment.Experiment
+	err := row.Scan(
+		&e.ID,
+		&e.Name,
+		&e.Template,
+		&e.Status,
+		&e.WinnerVariantID,
+		&e.CreatedAt,
+		&e.StoppedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (repo *RepositoryPG) variants(experimentID int) ([]experiment.Variant, error) {
+	query, args, err := sq.Select("id", "experiment_id", "name", "body", "weight").From("experiment_variants").
+		Where(sq.Eq{"experiment_id": experimentID}).
+		OrderBy("id ASC").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []experiment.Variant
+	for rows.Next() {
+		var v experiment.Variant
+		if err = rows.Scan(&v.ID, &v.ExperimentID, &v.Name, &v.Body, &v.Weight); err != nil {
+			return nil, err
+		}
+		variants = append(variants, v)
+	}
+	return variants, rows.Err()
+}
+
+func (repo *RepositoryPG) getExperiment(where sq.Sqlizer) (*experiment.Experiment, error) {
+	query, args, err := sq.Select(experimentColumns...).From("experiments").
+		Where(where).
+		OrderBy("id DESC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := scanExperiment(repo.db.QueryRow(query, args...))
+	if err != nil {
+		return nil, err
+	}
+	e.Variants, err = repo.variants(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (repo *RepositoryPG) GetExperiment(id int) (*experiment.Experiment, error) {
+	e, err := repo.getExperiment(sq.Eq{"id": id})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, appError.NotFound(errors.New("experiment does not exist"))
+	case err != nil:
+		return nil, err
+	default:
+		return e, nil
+	}
+}
+
+func (repo *RepositoryPG) GetActiveExperiment(template string) (*experiment.Experiment, error) {
+	e, err := repo.getExperiment(sq.Eq{
+		"template": template,
+		"status":   []string{experiment.StatusRunning, experiment.StatusPromoted},
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return e, nil
+	}
+}
+
+func (repo *RepositoryPG) GetExperiments() ([]experiment.Experiment, error) {
+	query, args, err := sq.Select(experimentColumns...).From("experiments").
+		OrderBy("created_at DESC").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var experiments []experiment.Experiment
+	for rows.Next() {
+		e, err := scanExperiment(rows)
+		if err != nil {
+			return nil, err
+		}
+		experiments = append(experiments, *e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range experiments {
+		experiments[i].Variants, err = repo.variants(experiments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return experiments, nil
+}
+
+func (repo *RepositoryPG) GetVariantMetrics(experimentID int) ([]experiment.VariantMetrics, error) {
+	// Only the latest snapshot of each tweet counts
+	query := `
+		WITH latest AS (
+			SELECT DISTINCT ON (tweet_id) post_id, impressions, likes + reposts + replies + quotes + bookmarks AS engagements
+			FROM tweet_metrics
+			ORDER BY tweet_id, collected_at DESC
+		)
+		SELECT p.variant_id,
+			COUNT(DISTINCT p.id),
+			COALESCE(SUM(l.impressions), 0),
+			COALESCE(SUM(l.engagements), 0)
+		FROM posts p
+		LEFT JOIN latest l ON l.post_id = p.id
+		WHERE p.experiment_id = $1 AND p.variant_id IS NOT NULL
+		GROUP BY p.variant_id`
+
+	rows, err := repo.db.Query(query, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metrics []experiment.VariantMetrics
+	for rows.Next() {
+		var m experiment.VariantMetrics
+		if err = rows.Scan(&m.VariantID, &m.Posts, &m.Impressions, &m.Engagements); err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, rows.Err()
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
+	"github.com/openai/openai-go"
+	"github.com/redis/go-redis/v9"
+)
+
+type postgresChecker struct {
+	db *sql.DB
+}
+
+func NewPostgresChecker(db *sql.DB) health.Checker {
+	return &postgresChecker{db: db}
+}
+
+func (checker *postgresChecker) Name() string {
+	return "postgres"
+}
+
+func (checker *postgresChecker) Check(ctx context.Context) error {
+	return checker.db.PingContext(ctx)
+}
+
+type redisChecker struct {
+	redis *redis.Client
+}
+
+func NewRedisChecker(redis *redis.Client) health.Checker {
+	return &redisChecker{redis: redis}
+}
+
+func (checker *redisChecker) Name() string {
+	return "redis"
+}
+
+func (checker *redisChecker) Check(ctx context.Context) error {
+	return checker.redis.Ping(ctx).Err()
+}
+
+// openAIChecker lists the models, which costs no tokens but still proves
+// the key is valid
+type openAIChecker struct {
+	client *openai.Client
+}
+
+func NewOpenAIChecker(client *openai.Client) health.Checker {
+	return &openAIChecker{client: client}
+}
+
+func (checker *openAIChecker) Name() string {
+	return "openai"
+}
+
+func (checker *openAIChecker) Check(ctx context.Context) error {
+	_, err := checker.client.Models.List(ctx)
+	return err
+}
+
+// schedulerChecker fails once the scheduler loop has not beat for maxAge,
+// which covers it being stuck as well as it never having started
+type schedulerChecker struct {
+	store  scheduler.Store
+	maxAge time.Duration
+}
+
+func NewSchedulerChecker(store scheduler.Store, maxAge time.Duration) health.Checker {
+	return &schedulerChecker{store: store, maxAge: maxAge}
+}
+
+func (checker *schedulerChecker) Name() string {
+	return "scheduler"
+}
+
+func (checker *schedulerChecker) Check(ctx context.Context) error {
+	lastBeat, err := checker.store.LastBeat()
+	if err != nil {
+		return err
+	}
+	if lastBeat == nil {
+		return fmt.Errorf("the scheduler has not started")
+	}
+	if age := time.Since(*lastBeat); age > checker.maxAge {
+		return fmt.Errorf("the scheduler last beat %v ago", age.Round(time.Second))
+	}
+	return nil
+}
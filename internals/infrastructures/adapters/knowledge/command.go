@@ -0,0 +1,40 @@
+package knowledge
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/pgvector/pgvector-go"
+)
+
+type RepositoryPG struct {
+	db         *sql.DB
+	embeddings *configs.Embeddings
+}
+
+func NewKnowledgeRepositoryPG(db *sql.DB, embeddings *configs.Embeddings) knowledge.Repository {
+	return &RepositoryPG{db: db, embeddings: embeddings}
+}
+
+func (repo *RepositoryPG) AddPassage(params *knowledge.AddPassageParams, embedding []float32) error {
+	model, _ := repo.embeddings.Active()
+	query, args, err := sq.Insert("knowledge_passages").
+		Columns("source", "content", "model", "dimensions", "embedding").
+		Values(params.Source, params.Content, model, len(embedding), pgvector.NewVector(embedding)).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
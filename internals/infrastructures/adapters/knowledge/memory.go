@@ -0,0 +1,98 @@
+package knowledge
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type storedPassage struct {
+	passage knowledge.Passage
+	model   string
+	vector  []float32
+}
+
+// MemoryRepository keeps passages in memory and ranks them by cosine
+// similarity by brute force. Like in Postgres, only passages embedded with
+// the active model are compared
+type MemoryRepository struct {
+	mutex      sync.Mutex
+	passages   []*storedPassage
+	embeddings *configs.Embeddings
+}
+
+func NewMemoryRepository(embeddings *configs.Embeddings) *MemoryRepository {
+	return &MemoryRepository{embeddings: embeddings}
+}
+
+func (repo *MemoryRepository) AddPassage(params *knowledge.AddPassageParams, vector []float32) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	model, _ := repo.embeddings.Active()
+	repo.passages = append(repo.passages, &storedPassage{
+		passage: knowledge.Passage{ID: len(repo.passages) + 1, Source: params.Source, Content: params.Content, CreatedAt: time.Now()},
+		model:   model,
+		vector:  vector,
+	})
+	return nil
+}
+
+func (repo *MemoryRepository) NearestPassages(vector []float32, limit int) ([]knowledge.ScoredPassage, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	model, _ := repo.embeddings.Active()
+	var passages []knowledge.ScoredPassage
+	for _, p := range repo.passages {
+		if p.model == model && len(p.vector) == len(vector) {
+			passages = append(passages, knowledge.ScoredPassage{Passage: p.passage, Similarity: cosine(p.vector, vector)})
+		}
+	}
+	sort.SliceStable(passages, func(i, j int) bool { return passages[i].Similarity > passages[j].Similarity })
+	return passages[:min(limit, len(passages))], nil
+}
+
+// PendingPassages returns every passage to re-embed, for embedding
+// migrations run in memory
+func (repo *MemoryRepository) PendingPassages() []embedding.Pending {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	pending := make([]embedding.Pending, 0, len(repo.passages))
+	for _, p := range repo.passages {
+		pending = append(pending, embedding.Pending{ID: p.passage.ID, Value: p.passage.Content, Passage: true})
+	}
+	return pending
+}
+
+// SwapEmbeddings replaces the vectors of passages with ones re-embedded with a model
+func (repo *MemoryRepository) SwapEmbeddings(model string, vectors map[int][]float32) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, p := range repo.passages {
+		if vector, ok := vectors[p.passage.ID]; ok {
+			p.vector, p.model = vector, model
+		}
+	}
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		normA += x * x
+		normB += y * y
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
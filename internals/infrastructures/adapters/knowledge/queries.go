@@ -0,0 +1,40 @@
+package knowledge
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/pgvector/pgvector-go"
+)
+
+// NearestPassages only compares passages embedded with the active model
+func (repo *RepositoryPG) NearestPassages(embedding []float32, limit int) ([]knowledge.ScoredPassage, error) {
+	model, _ := repo.embeddings.Active()
+	// <=> is cosine distance, so 1 - distance gives cosine similarity
+	query, _, err := sq.Select("id", "source", "content", "created_at", "1 - (embedding <=> $1) AS similarity").
+		From("knowledge_passages").
+		Where("model = $2").
+		OrderBy("embedding <=> $1").
+		Suffix("LIMIT $3").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := repo.db.Query(query, pgvector.NewVector(embedding), model, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	var passages []knowledge.ScoredPassage
+	for rows.Next() {
+		var passage knowledge.ScoredPassage
+		if err = rows.Scan(&passage.ID, &passage.Source, &passage.Content, &passage.CreatedAt, &passage.Similarity); err != nil {
+			return nil, err
+		}
+		passages = append(passages, passage)
+	}
+	return passages, rows.Err()
+}
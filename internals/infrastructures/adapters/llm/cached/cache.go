@@ -0,0 +1,178 @@
+package cached
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/cache"
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+// Cache keeps embeddings in Redis, and in Postgres when the cache is
+// persisted, so the same text is only embedded once per model
+type Cache struct {
+	embedder embedding.Embedder
+	cache    cache.Repository
+	store    embedding.CacheRepository
+	config   *configs.EmbeddingCache
+	hits     atomic.Int64
+	misses   atomic.Int64
+}
+
+// NewEmbeddingCache wraps an embedder. store may be nil, which keeps
+// embeddings in Redis only
+func NewEmbeddingCache(embedder embedding.Embedder, cache cache.Repository, store embedding.CacheRepository, config *configs.EmbeddingCache) *Cache {
+	return &Cache{embedder: embedder, cache: cache, store: store, config: config}
+}
+
+// Embed returns the cached embeddings of values and embeds the rest in one
+// request. Values are embedded with their whitespace normalised, which is
+// what they are cached by
+func (c *Cache) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	if !c.config.Enabled {
+		return c.embedder.Embed(model, dimensions, values)
+	}
+
+	embeddings := make([][]float32, len(values))
+	keys := make([]string, len(values))
+	missing := map[string][]int{}
+	for i, value := range values {
+		keys[i] = Key(model, dimensions, value)
+		if vector := c.get(keys[i]); vector != nil {
+			embeddings[i] = vector
+		} else {
+			missing[keys[i]] = append(missing[keys[i]], i)
+		}
+	}
+
+	if len(missing) > 0 && c.store != nil {
+		stored, err := c.store.GetCached(mapKeys(missing))
+		if err != nil {
+			fmt.Println(err)
+		}
+		for key, vector := range stored {
+			c.set(key, vector)
+			for _, i := range missing[key] {
+				embeddings[i] = vector
+			}
+			delete(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		// Texts repeated in a batch are only embedded once
+		texts := make([]string, 0, len(missing))
+		order := make([]string, 0, len(missing))
+		for key, indexes := range missing {
+			texts = append(texts, Normalize(values[indexes[0]]))
+			order = append(order, key)
+		}
+		vectors, err := c.embedder.Embed(model, dimensions, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(texts) {
+			return nil, fmt.Errorf("%s returned %d embeddings for %d values", model, len(vectors), len(texts))
+		}
+
+		fresh := make(map[string][]float32, len(order))
+		for j, key := range order {
+			fresh[key] = vectors[j]
+			c.set(key, vectors[j])
+			for _, i := range missing[key] {
+				embeddings[i] = vectors[j]
+			}
+		}
+		if c.store != nil {
+			if err = c.store.AddCached(model, fresh); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}
+
+	c.misses.Add(int64(len(missing)))
+	c.hits.Add(int64(len(values) - len(missing)))
+	return embeddings, nil
+}
+
+func (c *Cache) CacheStats() embedding.CacheStats {
+	stats := embedding.CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// get returns the embedding cached under a key, or nil on a miss. Redis
+// being unavailable only costs a request to the provider
+func (c *Cache) get(key string) []float32 {
+	value, err := c.cache.Get(key)
+	if err != nil || value == "" {
+		return nil
+	}
+	vector, err := decode(value)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	return vector
+}
+
+func (c *Cache) set(key string, vector []float32) {
+	if err := c.cache.Set(key, encode(vector), c.config.TTL); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// Normalize collapses the whitespace of a text, which doesn't change its meaning
+func Normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Key is what an embedding is cached by: the model and dimensions it was
+// made with and a SHA-256 of the normalised text
+func Key(model string, dimensions int, text string) string {
+	sum := sha256.Sum256([]byte(Normalize(text)))
+	return "embedding:" + model + ":" + strconv.Itoa(dimensions) + ":" + hex.EncodeToString(sum[:])
+}
+
+// encode packs a vector into four little-endian bytes a value, which is
+// far smaller than JSON
+func encode(vector []float32) string {
+	buffer := make([]byte, 4*len(vector))
+	for i, value := range vector {
+		binary.LittleEndian.PutUint32(buffer[4*i:], math.Float32bits(value))
+	}
+	return base64.StdEncoding.EncodeToString(buffer)
+}
+
+func decode(value string) ([]float32, error) {
+	buffer, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(buffer)%4 != 0 {
+		return nil, fmt.Errorf("cached embedding has %d bytes", len(buffer))
+	}
+	vector := make([]float32, len(buffer)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buffer[4*i:]))
+	}
+	return vector, nil
+}
+
+func mapKeys(m map[string][]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
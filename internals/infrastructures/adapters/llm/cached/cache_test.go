@@ -0,0 +1,147 @@
+package cached
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// mapCache is a Redis stand-in that never expires
+type mapCache struct {
+	values map[string]string
+}
+
+func (c *mapCache) Set(key string, value string, expiration time.Duration) error {
+	c.values[key] = value
+	return nil
+}
+
+func (c *mapCache) Get(key string) (string, error) {
+	value, ok := c.values[key]
+	if !ok {
+		return "", errors.New("redis: nil")
+	}
+	return value, nil
+}
+
+func (c *mapCache) TTL(key string) (time.Duration, error) {
+	return 0, nil
+}
+
+// mapStore is a Postgres stand-in
+type mapStore struct {
+	values map[string][]float32
+}
+
+func (s *mapStore) GetCached(keys []string) (map[string][]float32, error) {
+	found := map[string][]float32{}
+	for _, key := range keys {
+		if vector, ok := s.values[key]; ok {
+			found[key] = vector
+		}
+	}
+	return found, nil
+}
+
+func (s *mapStore) AddCached(model string, embeddings map[string][]float32) error {
+	for key, vector := range embeddings {
+		s.values[key] = vector
+	}
+	return nil
+}
+
+// countingEmbedder embeds every value as its length and keeps what it was sent
+type countingEmbedder struct {
+	requests [][]string
+}
+
+func (e *countingEmbedder) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	e.requests = append(e.requests, values)
+	vectors := make([][]float32, len(values))
+	for i, value := range values {
+		vectors[i] = []float32{float32(len(value)), 0.5}
+	}
+	return vectors, nil
+}
+
+func TestCache_Embed(t *testing.T) {
+	embedder := &countingEmbedder{}
+	store := &mapStore{values: map[string][]float32{}}
+	c := NewEmbeddingCache(embedder, &mapCache{values: map[string]string{}}, store, &configs.EmbeddingCache{Enabled: true, TTL: time.Hour})
+
+	vectors, err := c.Embed("model", 0, []string{"XCM fees", " XCM   fees ", "OpenGov"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{8, 0.5}, {8, 0.5}, {7, 0.5}}, vectors)
+	assert.Len(t, embedder.requests, 1)
+	assert.ElementsMatch(t, []string{"XCM fees", "OpenGov"}, embedder.requests[0])
+	assert.Len(t, store.values, 2)
+
+	vectors, err = c.Embed("model", 0, []string{"OpenGov", "parachains"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{7, 0.5}, {10, 0.5}}, vectors)
+	assert.Equal(t, []string{"parachains"}, embedder.requests[1])
+
+	_, err = c.Embed("other-model", 0, []string{"OpenGov"})
+	assert.NoError(t, err)
+	assert.Len(t, embedder.requests, 3)
+
+	stats := c.CacheStats()
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(4), stats.Misses)
+	assert.InDelta(t, 2.0/6, stats.HitRate, 0.001)
+}
+
+func TestCache_Embed_Persisted(t *testing.T) {
+	embedder := &countingEmbedder{}
+	redis := &mapCache{values: map[string]string{}}
+	store := &mapStore{values: map[string][]float32{Key("model", 0, "XCM fees"): {1, 2}}}
+	c := NewEmbeddingCache(embedder, redis, store, &configs.EmbeddingCache{Enabled: true, TTL: time.Hour})
+
+	vectors, err := c.Embed("model", 0, []string{"XCM fees"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 2}}, vectors)
+	assert.Empty(t, embedder.requests)
+	assert.Contains(t, redis.values, Key("model", 0, "XCM fees"))
+}
+
+func TestCache_Embed_Disabled(t *testing.T) {
+	embedder := &countingEmbedder{}
+	c := NewEmbeddingCache(embedder, &mapCache{values: map[string]string{}}, nil, &configs.EmbeddingCache{})
+
+	for range 2 {
+		_, err := c.Embed("model", 0, []string{"XCM fees"})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, embedder.requests, 2)
+	assert.Zero(t, c.CacheStats().Hits)
+}
+
+// shortEmbedder drops the last embedding of every request
+type shortEmbedder struct{}
+
+func (e *shortEmbedder) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	return make([][]float32, len(values)-1), nil
+}
+
+func TestCache_Embed_MissingEmbeddings(t *testing.T) {
+	redis := &mapCache{values: map[string]string{}}
+	c := NewEmbeddingCache(&shortEmbedder{}, redis, nil, &configs.EmbeddingCache{Enabled: true, TTL: time.Hour})
+
+	// Nothing is cached against the wrong value
+	_, err := c.Embed("model", 0, []string{"XCM fees", "OpenGov"})
+	assert.EqualError(t, err, "model returned 1 embeddings for 2 values")
+	assert.Empty(t, redis.values)
+}
+
+func TestEncode(t *testing.T) {
+	vector := []float32{0.25, -1.5, 3e-7, 0}
+	decoded, err := decode(encode(vector))
+	assert.NoError(t, err)
+	assert.Equal(t, vector, decoded)
+
+	_, err = decode("AAA=")
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+package cached
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+// Repository embeds through a cache with the configured model and leaves
+// prompts to the llm.Repository it wraps
+type Repository struct {
+	llm        llm.Repository
+	cache      embedding.Embedder
+	embeddings *configs.Embeddings
+}
+
+func NewCachedRepository(llm llm.Repository, cache embedding.Embedder, embeddings *configs.Embeddings) llm.Repository {
+	return &Repository{llm: llm, cache: cache, embeddings: embeddings}
+}
+
+func (repo *Repository) Prompt(prompt string) (string, error) {
+	return repo.llm.Prompt(prompt)
+}
+
+func (repo *Repository) Embed(prompt string) ([]float32, error) {
+	model, dimensions := repo.embeddings.Active()
+	embeddings, err := repo.cache.Embed(model, dimensions, []string{prompt})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
@@ -0,0 +1,105 @@
+package hashing
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+// DefaultDimensions matches the embeddings column the migrations create
+const DefaultDimensions = 3072
+
+// Weights of each kind of feature. Words carry the meaning, character
+// trigrams let inflections and typos still overlap
+const (
+	wordWeight    = 1.0
+	bigramWeight  = 1.0
+	trigramWeight = 0.5
+)
+
+// Repository embeds locally and leaves prompts to another llm.Repository
+type Repository struct {
+	llm        llm.Repository
+	embeddings *configs.Embeddings
+}
+
+func NewHashingRepository(llm llm.Repository, embeddings *configs.Embeddings) llm.Repository {
+	return &Repository{llm: llm, embeddings: embeddings}
+}
+
+func (repo *Repository) Prompt(prompt string) (string, error) {
+	return repo.llm.Prompt(prompt)
+}
+
+func (repo *Repository) Embed(prompt string) ([]float32, error) {
+	_, dimensions := repo.embeddings.Active()
+	return Embed(prompt, dimensions), nil
+}
+
+type Embedder struct{}
+
+func NewEmbedder() embedding.Embedder {
+	return &Embedder{}
+}
+
+// Embed embeds values locally whatever model is asked for
+func (embedder *Embedder) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(values))
+	for i, value := range values {
+		embeddings[i] = Embed(value, dimensions)
+	}
+	return embeddings, nil
+}
+
+// Embed hashes the words, word bigrams and character trigrams of a text
+// into a vector of a fixed dimension, DefaultDimensions when it is zero.
+// A bit of each hash picks the sign, so collisions cancel out rather than
+// pile up. The vector has unit length, so every metric ranks alike
+func Embed(text string, dimensions int) []float32 {
+	if dimensions <= 0 {
+		dimensions = DefaultDimensions
+	}
+	vector := make([]float64, dimensions)
+	add := func(feature string, weight float64) {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(feature))
+		sum := hash.Sum64()
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vector[sum%uint64(dimensions)] += weight
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for i, word := range words {
+		add("w:"+word, wordWeight)
+		if i > 0 {
+			add("b:"+words[i-1]+" "+word, bigramWeight)
+		}
+		padded := []rune("#" + word + "#")
+		for j := 0; j+3 <= len(padded); j++ {
+			add("c:"+string(padded[j:j+3]), trigramWeight)
+		}
+	}
+
+	var norm float64
+	for _, value := range vector {
+		norm += value * value
+	}
+	norm = math.Sqrt(norm)
+
+	embedding := make([]float32, dimensions)
+	for i, value := range vector {
+		if norm > 0 {
+			embedding[i] = float32(value / norm)
+		}
+	}
+	return embedding
+}
@@ -0,0 +1,42 @@
+package hashing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+func TestEmbed(t *testing.T) {
+	vector := Embed("Polkadot's shared security protects every parachain", 0)
+	assert.Len(t, vector, DefaultDimensions)
+	assert.Equal(t, vector, Embed("polkadot s SHARED security, protects every parachain!", 0))
+	assert.Len(t, Embed("XCM", 256), 256)
+
+	var norm float64
+	for _, value := range vector {
+		norm += float64(value) * float64(value)
+	}
+	assert.InDelta(t, 1, math.Sqrt(norm), 0.0001)
+
+	similar := Embed("Shared security on Polkadot protects each parachain", 0)
+	unrelated := Embed("OpenGov lets any DOT holder submit a referendum", 0)
+	assert.Greater(t, cosine(vector, similar), cosine(vector, unrelated))
+	assert.Greater(t, cosine(vector, similar), 0.5)
+
+	assert.Equal(t, make([]float32, 8), Embed("...", 8))
+}
+
+func TestEmbedder_Embed(t *testing.T) {
+	vectors, err := NewEmbedder().Embed("any-model", 64, []string{"XCM fees", "OpenGov"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{Embed("XCM fees", 64), Embed("OpenGov", 64)}, vectors)
+}
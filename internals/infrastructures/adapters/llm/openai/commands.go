@@ -2,26 +2,36 @@ package openai
 
 import (
 	"context"
+	"errors"
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
 	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
 	"github.com/openai/openai-go"
-	"github.com/openai/openai-go/shared"
 )
 
+// missingKeyErr is returned by calls made without a client, which memory
+// mode and local embeddings run without
+var missingKeyErr = errors.New("OPENAI_API_KEY is not set")
+
 type Repository struct {
-	client *openai.Client
+	client     *openai.Client
+	model      openai.ChatModel
+	embeddings *configs.Embeddings
 }
 
-func NewOpenAIRepository(client *openai.Client) llm.Repository {
-	return &Repository{client: client}
+func NewOpenAIRepository(client *openai.Client, model string, embeddings *configs.Embeddings) llm.Repository {
+	return &Repository{client: client, model: openai.ChatModel(model), embeddings: embeddings}
 }
 
 func (repo *Repository) Prompt(prompt string) (string, error) {
-
+	if repo.client == nil {
+		return "", missingKeyErr
+	}
 	chatCompletion, err := repo.client.Chat.Completions.New(context.TODO(), openai.ChatCompletionNewParams{
 		Messages: openai.F([]openai.ChatCompletionMessageParamUnion{
 			openai.UserMessage(prompt),
 		}),
-		Model: openai.F(openai.ChatModelGPT4o),
+		Model: openai.F(repo.model),
 	})
 	if err != nil {
 		return "", err
@@ -30,16 +40,49 @@ func (repo *Repository) Prompt(prompt string) (string, error) {
 }
 
 func (repo *Repository) Embed(prompt string) ([]float32, error) {
-	response, err := repo.client.Embeddings.New(context.TODO(), openai.EmbeddingNewParams{
-		Input:          openai.F[openai.EmbeddingNewParamsInputUnion](shared.UnionString(prompt)),
-		Model:          openai.F(openai.EmbeddingModelTextEmbedding3Large),
+	model, dimensions := repo.embeddings.Active()
+	embeddings, err := embed(repo.client, model, dimensions, []string{prompt})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+type Embedder struct {
+	client *openai.Client
+}
+
+func NewEmbedder(client *openai.Client) embedding.Embedder {
+	return &Embedder{client: client}
+}
+
+func (embedder *Embedder) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	return embed(embedder.client, model, dimensions, values)
+}
+
+// embed embeds values in one request, in the order they were given
+func embed(client *openai.Client, model string, dimensions int, values []string) ([][]float32, error) {
+	if client == nil {
+		return nil, missingKeyErr
+	}
+	params := openai.EmbeddingNewParams{
+		Input:          openai.F[openai.EmbeddingNewParamsInputUnion](openai.EmbeddingNewParamsInputArrayOfStrings(values)),
+		Model:          openai.F(openai.EmbeddingModel(model)),
 		EncodingFormat: openai.F(openai.EmbeddingNewParamsEncodingFormatFloat),
-	})
+	}
+	if dimensions > 0 {
+		params.Dimensions = openai.F(int64(dimensions))
+	}
+	response, err := client.Embeddings.New(context.TODO(), params)
 	if err != nil {
 		return nil, err
 	}
-	embedding := response.Data
-	return formatEmbedding(embedding[0].Embedding), err
+
+	embeddings := make([][]float32, len(values))
+	for _, data := range response.Data {
+		embeddings[data.Index] = formatEmbedding(data.Embedding)
+	}
+	return embeddings, nil
 }
 
 func formatEmbedding(embedding []float64) []float32 {
@@ -0,0 +1,59 @@
+package mention
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+)
+
+type RepositoryPG struct {
+	db *sql.DB
+}
+
+func NewMentionRepositoryPG(db *sql.DB) mention.Repository {
+	return &RepositoryPG{db: db}
+}
+
+func (repo *RepositoryPG) AddMention(params *mention.Mention) error {
+	query, args, err := sq.Insert("mentions").
+		Columns("tweet_id", "author_id", "author_username", "text", "classification", "confidence", "draft", "status").
+		Values(params.TweetID, params.AuthorID, params.AuthorUsername, params.Text, params.Classification, params.Confidence, params.Draft, params.Status).
+		Suffix("ON CONFLICT (tweet_id) DO NOTHING").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
+
+func (repo *RepositoryPG) UpdateMention(params *mention.Mention) error {
+	query, args, err := sq.Update("mentions").SetMap(map[string]interface{}{
+		"draft":          params.Draft,
+		"status":         params.Status,
+		"reply_tweet_id": params.ReplyTweetID,
+		"updated_at":     sq.Expr("CURRENT_TIMESTAMP"),
+	}).Where(sq.Eq{"id": params.ID}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
@@ -0,0 +1,99 @@
+package mention
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// MemoryRepository keeps mentions in memory, each tweet at most once as in
+// the mentions table
+type MemoryRepository struct {
+	mutex    sync.Mutex
+	nextID   int
+	mentions []*mention.Mention
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) AddMention(params *mention.Mention) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, m := range repo.mentions {
+		if m.TweetID == params.TweetID {
+			return nil
+		}
+	}
+	repo.nextID++
+	m := *params
+	m.ID = repo.nextID
+	m.CreatedAt, m.UpdatedAt = time.Now(), time.Now()
+	repo.mentions = append(repo.mentions, &m)
+	return nil
+}
+
+func (repo *MemoryRepository) GetMention(id int) (*mention.Mention, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, m := range repo.mentions {
+		if m.ID == id {
+			found := *m
+			return &found, nil
+		}
+	}
+	return nil, appError.NotFound(errors.New("mention does not exist"))
+}
+
+func (repo *MemoryRepository) GetMentions(params *mention.GetMentionsParams) ([]mention.Mention, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultMentionsLimit
+	}
+	var mentions []mention.Mention
+	for _, m := range repo.mentions {
+		if len(mentions) == limit {
+			break
+		}
+		if params.Status == "" || m.Status == params.Status {
+			mentions = append(mentions, *m)
+		}
+	}
+	return mentions, nil
+}
+
+func (repo *MemoryRepository) UpdateMention(params *mention.Mention) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, m := range repo.mentions {
+		if m.ID == params.ID {
+			m.Draft, m.Status, m.ReplyTweetID = params.Draft, params.Status, params.ReplyTweetID
+			m.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) LatestTweetID() (string, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Tweet IDs are snowflakes, so the numerically largest one is the newest
+	latest := ""
+	for _, m := range repo.mentions {
+		if len(m.TweetID) > len(latest) || (len(m.TweetID) == len(latest) && m.TweetID > latest) {
+			latest = m.TweetID
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,118 @@
+package mention
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+const defaultMentionsLimit = 50
+
+var mentionColumns = []string{
+	"id", "tweet_id", "author_id", "author_username", "text", "classification",
+	"confidence", "draft", "status", "reply_tweet_id", "created_at", "updated_at",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMention(row scanner) (*mention.Mention, error) {
+	var m mention.Mention
+	err := row.Scan(
+		&m.ID,
+		&m.TweetID,
+		&m.AuthorID,
+		&m.AuthorUsername,
+		&m.Text,
+		&m.Classification,
+		&m.Confidence,
+		&m.Draft,
+		&m.Status,
+		&m.ReplyTweetID,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (repo *RepositoryPG) GetMention(id int) (*mention.Mention, error) {
+	query, args, err := sq.Select(mentionColumns...).From("mentions").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := scanMention(repo.db.QueryRow(query, args...))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, appError.NotFound(errors.New("mention does not exist"))
+	case err != nil:
+		return nil, err
+	default:
+		return m, nil
+	}
+}
+
+func (repo *RepositoryPG) GetMentions(params *mention.GetMentionsParams) ([]mention.Mention, error) {
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultMentionsLimit
+	}
+
+	builder := sq.Select(mentionColumns...).From("mentions").
+		OrderBy("created_at ASC").
+		Limit(uint64(limit))
+	if params.Status != "" {
+		builder = builder.Where(sq.Eq{"status": params.Status})
+	}
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []mention.Mention
+	for rows.Next() {
+		m, err := scanMention(rows)
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, *m)
+	}
+	return mentions, rows.Err()
+}
+
+func (repo *RepositoryPG) LatestTweetID() (string, error) {
+	// Tweet IDs are snowflakes, so the numerically largest one is the newest
+	query, args, err := sq.Select("tweet_id").From("mentions").
+		OrderBy("LENGTH(tweet_id) DESC", "tweet_id DESC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var tweetID string
+	err = repo.db.QueryRow(query, args...).Scan(&tweetID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return "", nil
+	case err != nil:
+		return "", err
+	default:
+		return tweetID, nil
+	}
+}
@@ -0,0 +1,116 @@
+package persona
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/lib/pq"
+)
+
+type RepositoryPG struct {
+	db *sql.DB
+}
+
+func NewPersonaRepositoryPG(db *sql.DB) persona.Repository {
+	return &RepositoryPG{db: db}
+}
+
+// clearDefault unsets the current default persona so a new one can take its place
+func clearDefault(tx *sql.Tx, exceptID int) error {
+	query, args, err := sq.Update("personas").
+		Set("is_default", false).
+		Where(sq.And{sq.Eq{"is_default": true}, sq.NotEq{"id": exceptID}}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(query, args...)
+	return err
+}
+
+func (repo *RepositoryPG) AddPersona(params *persona.Persona) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if params.IsDefault {
+		if err = clearDefault(tx, 0); err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Insert("personas").
+		Columns("name", "description", "tone", "reading_level", "banned_words", "max_hashtags", "max_emojis",
+			"signature_phrases", "example_posts", "categories", "is_default").
+		Values(params.Name, params.Description, params.Tone, params.ReadingLevel, pq.Array(params.BannedWords), params.MaxHashtags, params.MaxEmojis,
+			pq.Array(params.SignaturePhrases), pq.Array(params.ExamplePosts), pq.Array(params.Categories), params.IsDefault).
+		Suffix("RETURNING id, created_at, updated_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if err = tx.QueryRow(query, args...).Scan(&params.ID, &params.CreatedAt, &params.UpdatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *RepositoryPG) UpdatePersona(params *persona.Persona) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if params.IsDefault {
+		if err = clearDefault(tx, params.ID); err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Update("personas").SetMap(map[string]interface{}{
+		"name":              params.Name,
+		"description":       params.Description,
+		"tone":              params.Tone,
+		"reading_level":     params.ReadingLevel,
+		"banned_words":      pq.Array(params.BannedWords),
+		"max_hashtags":      params.MaxHashtags,
+		"max_emojis":        params.MaxEmojis,
+		"signature_phrases": pq.Array(params.SignaturePhrases),
+		"example_posts":     pq.Array(params.ExamplePosts),
+		"categories":        pq.Array(params.Categories),
+		"is_default":        params.IsDefault,
+		"updated_at":        sq.Expr("CURRENT_TIMESTAMP"),
+	}).Where(sq.Eq{"id": params.ID}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *RepositoryPG) DeletePersona(id int) error {
+	query, args, err := sq.Delete("personas").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
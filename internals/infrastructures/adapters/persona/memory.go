@@ -0,0 +1,141 @@
+package persona
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// MemoryRepository keeps personas in memory with the constraints of the
+// personas table: names are unique and only one persona is the default.
+// It starts with the personas the migration seeds
+type MemoryRepository struct {
+	mutex    sync.Mutex
+	nextID   int
+	personas []*persona.Persona
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	repo := &MemoryRepository{}
+	for _, p := range persona.Seeded() {
+		_ = repo.AddPersona(&p)
+	}
+	return repo
+}
+
+func (repo *MemoryRepository) AddPersona(params *persona.Persona) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if err := repo.checkName(params.Name, 0); err != nil {
+		return err
+	}
+	if params.IsDefault {
+		repo.clearDefault(0)
+	}
+	repo.nextID++
+	params.ID = repo.nextID
+	params.CreatedAt, params.UpdatedAt = time.Now(), time.Now()
+	added := *params
+	repo.personas = append(repo.personas, &added)
+	return nil
+}
+
+func (repo *MemoryRepository) GetPersona(id int) (*persona.Persona, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, p := range repo.personas {
+		if p.ID == id {
+			found := *p
+			return &found, nil
+		}
+	}
+	return nil, appError.NotFound(errors.New("persona does not exist"))
+}
+
+func (repo *MemoryRepository) GetPersonas() ([]persona.Persona, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	personas := make([]persona.Persona, 0, len(repo.personas))
+	for _, p := range repo.personas {
+		personas = append(personas, *p)
+	}
+	sort.Slice(personas, func(i, j int) bool { return personas[i].Name < personas[j].Name })
+	return personas, nil
+}
+
+func (repo *MemoryRepository) GetPersonaForCategory(category string) (*persona.Persona, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// A persona mapped to the category wins over the default one
+	var fallback *persona.Persona
+	for _, p := range repo.personas {
+		if slices.Contains(p.Categories, category) {
+			found := *p
+			return &found, nil
+		}
+		if p.IsDefault {
+			fallback = p
+		}
+	}
+	if fallback == nil {
+		return nil, appError.NotFound(errors.New("no persona for category and no default persona"))
+	}
+	found := *fallback
+	return &found, nil
+}
+
+func (repo *MemoryRepository) UpdatePersona(params *persona.Persona) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if err := repo.checkName(params.Name, params.ID); err != nil {
+		return err
+	}
+	if params.IsDefault {
+		repo.clearDefault(params.ID)
+	}
+	for i, p := range repo.personas {
+		if p.ID == params.ID {
+			updated := *params
+			updated.CreatedAt, updated.UpdatedAt = p.CreatedAt, time.Now()
+			repo.personas[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) DeletePersona(id int) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.personas = slices.DeleteFunc(repo.personas, func(p *persona.Persona) bool { return p.ID == id })
+	return nil
+}
+
+func (repo *MemoryRepository) checkName(name string, exceptID int) error {
+	for _, p := range repo.personas {
+		if p.Name == name && p.ID != exceptID {
+			return appError.Conflict(fmt.Errorf("persona %q already exists", name))
+		}
+	}
+	return nil
+}
+
+// clearDefault unsets the current default persona so a new one can take its place
+func (repo *MemoryRepository) clearDefault(exceptID int) {
+	for _, p := range repo.personas {
+		if p.ID != exceptID {
+			p.IsDefault = false
+		}
+	}
+}
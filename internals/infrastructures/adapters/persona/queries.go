@@ -0,0 +1,110 @@
+package persona
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/lib/pq"
+)
+
+var personaColumns = []string{
+	"id", "name", "description", "tone", "reading_level", "banned_words", "max_hashtags", "max_emojis",
+	"signature_phrases", "example_posts", "categories", "is_default", "created_at", "updated_at",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPersona(row scanner) (*persona.Persona, error) {
+	var p persona.Persona
+	err := row.Scan(
+		&p.ID,
+		&p.Name,
+		&p.Description,
+		&p.Tone,
+		&p.ReadingLevel,
+		pq.Array(&p.BannedWords),
+		&p.MaxHashtags,
+		&p.MaxEmojis,
+		pq.Array(&p.SignaturePhrases),
+		pq.Array(&p.ExamplePosts),
+		pq.Array(&p.Categories),
+		&p.IsDefault,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (repo *RepositoryPG) GetPersona(id int) (*persona.Persona, error) {
+	query, args, err := sq.Select(personaColumns...).From("personas").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := scanPersona(repo.db.QueryRow(query, args...))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, appError.NotFound(errors.New("persona does not exist"))
+	case err != nil:
+		return nil, err
+	default:
+		return p, nil
+	}
+}
+
+func (repo *RepositoryPG) GetPersonaForCategory(category string) (*persona.Persona, error) {
+	// A persona mapped to the category wins over the default one
+	query, args, err := sq.Select(personaColumns...).From("personas").
+		Where(sq.Or{sq.Expr("? = ANY(categories)", category), sq.Eq{"is_default": true}}).
+		OrderBy("is_default ASC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := scanPersona(repo.db.QueryRow(query, args...))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, appError.NotFound(errors.New("no persona for category and no default persona"))
+	case err != nil:
+		return nil, err
+	default:
+		return p, nil
+	}
+}
+
+func (repo *RepositoryPG) GetPersonas() ([]persona.Persona, error) {
+	query, args, err := sq.Select(personaColumns...).From("personas").
+		OrderBy("name ASC").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var personas []persona.Persona
+	for rows.Next() {
+		p, err := scanPersona(rows)
+		if err != nil {
+			return nil, err
+		}
+		personas = append(personas, *p)
+	}
+	return personas, rows.Err()
+}
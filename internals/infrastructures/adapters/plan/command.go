@@ -0,0 +1,32 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "content_plan:"
+	// retention keeps a week of plans around to compare against what went out
+	retention = 7 * 24 * time.Hour
+)
+
+type RedisRepository struct {
+	redis *redis.Client
+}
+
+func NewPlanRepositoryRedis(redis *redis.Client) plan.Repository {
+	return &RedisRepository{redis: redis}
+}
+
+func (repo *RedisRepository) SavePlan(params *plan.Plan) error {
+	value, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return repo.redis.Set(context.Background(), keyPrefix+params.Date, value, retention).Err()
+}
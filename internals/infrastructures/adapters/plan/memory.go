@@ -0,0 +1,38 @@
+package plan
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// MemoryRepository keeps plans in memory for as long as the app runs
+type MemoryRepository struct {
+	mutex sync.Mutex
+	plans map[string]plan.Plan
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{plans: map[string]plan.Plan{}}
+}
+
+func (repo *MemoryRepository) SavePlan(params *plan.Plan) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.plans[params.Date] = *params
+	return nil
+}
+
+func (repo *MemoryRepository) GetPlan(date string) (*plan.Plan, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	p, ok := repo.plans[date]
+	if !ok {
+		return nil, appError.NotFound(fmt.Errorf("no plan for %s", date))
+	}
+	return &p, nil
+}
@@ -0,0 +1,82 @@
+package plan
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPlanRepositoryPG(db *sql.DB) plan.Repository {
+	return &PostgresRepository{db: db}
+}
+
+func (repo *PostgresRepository) SavePlan(params *plan.Plan) error {
+	value, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = sq.Insert("content_plans").
+		Columns("date", "plan").
+		Values(params.Date, value).
+		Suffix("ON CONFLICT (date) DO UPDATE SET plan = EXCLUDED.plan, created_at = CURRENT_TIMESTAMP").
+		PlaceholderFormat(sq.Dollar).
+		RunWith(tx).Exec()
+	if err != nil {
+		return err
+	}
+
+	// Plans expire after the retention, as they do in Redis
+	_, err = sq.Delete("content_plans").
+		Where(sq.Lt{"created_at": time.Now().Add(-retention)}).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(tx).Exec()
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *PostgresRepository) GetPlan(date string) (*plan.Plan, error) {
+	query, args, err := sq.Select("plan").
+		From("content_plans").
+		Where(sq.Eq{"date": date}).
+		Where(sq.GtOrEq{"created_at": time.Now().Add(-retention)}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	err = repo.db.QueryRow(query, args...).Scan(&value)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, appError.NotFound(fmt.Errorf("no plan for %s", date))
+	case err != nil:
+		return nil, err
+	}
+
+	var p plan.Plan
+	if err = json.Unmarshal(value, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
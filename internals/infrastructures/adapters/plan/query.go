@@ -0,0 +1,28 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/redis/go-redis/v9"
+)
+
+func (repo *RedisRepository) GetPlan(date string) (*plan.Plan, error) {
+	value, err := repo.redis.Get(context.Background(), keyPrefix+date).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, appError.NotFound(fmt.Errorf("no plan for %s", date))
+	case err != nil:
+		return nil, err
+	}
+
+	var p plan.Plan
+	if err = json.Unmarshal(value, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,39 @@
+package policy
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+)
+
+type RepositoryPG struct {
+	db *sql.DB
+}
+
+func NewPolicyRepositoryPG(db *sql.DB) policy.Repository {
+	return &RepositoryPG{db: db}
+}
+
+func (repo *RepositoryPG) AddBlocked(params *policy.Blocked) error {
+	draft, err := json.Marshal(params.Draft)
+	if err != nil {
+		return err
+	}
+	violations, err := json.Marshal(params.Violations)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := sq.Insert("blocked_drafts").
+		Columns("topic", "draft", "violations").
+		Values(params.Draft.Topic, draft, violations).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return repo.db.QueryRow(query, args...).Scan(&params.ID, &params.CreatedAt)
+}
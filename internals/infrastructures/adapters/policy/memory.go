@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+)
+
+// MemoryRepository keeps blocked drafts in memory for as long as the app runs
+type MemoryRepository struct {
+	mutex   sync.Mutex
+	blocked []policy.Blocked
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) AddBlocked(params *policy.Blocked) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	params.ID = len(repo.blocked) + 1
+	params.CreatedAt = time.Now()
+	repo.blocked = append(repo.blocked, *params)
+	return nil
+}
+
+func (repo *MemoryRepository) GetBlocked(limit int) ([]policy.Blocked, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Newest first
+	blocked := make([]policy.Blocked, 0, min(limit, len(repo.blocked)))
+	for i := len(repo.blocked) - 1; i >= 0 && len(blocked) < limit; i-- {
+		blocked = append(blocked, repo.blocked[i])
+	}
+	return blocked, nil
+}
@@ -0,0 +1,41 @@
+package policy
+
+import (
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+)
+
+func (repo *RepositoryPG) GetBlocked(limit int) ([]policy.Blocked, error) {
+	query, args, err := sq.Select("id", "draft", "violations", "created_at").From("blocked_drafts").
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocked []policy.Blocked
+	for rows.Next() {
+		var b policy.Blocked
+		var draft, violations []byte
+		if err = rows.Scan(&b.ID, &draft, &violations, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(draft, &b.Draft); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(violations, &b.Violations); err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, b)
+	}
+	return blocked, rows.Err()
+}
@@ -0,0 +1,85 @@
+package post
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/lib/pq"
+)
+
+type RepositoryPG struct {
+	db *sql.DB
+}
+
+func NewPostRepositoryPG(db *sql.DB) post.Repository {
+	return &RepositoryPG{db: db}
+}
+
+func (repo *RepositoryPG) AddPost(params *post.Post) error {
+	claims, err := json.Marshal(params.Claims)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := sq.Insert("posts").
+		Columns("topic", "topic_type", "category", "format", "prompt_template", "prompt_version", "experiment_id", "variant_id", "persona_id", "candidate_batch", "tweet_ids", "claims", "posted_at").
+		Values(params.Topic, params.TopicType, params.Category, params.Format, params.PromptTemplate, params.PromptVersion, params.ExperimentID, params.VariantID, params.PersonaID, sql.NullString{String: params.CandidateBatch, Valid: params.CandidateBatch != ""}, pq.Array(params.TweetIDs), claims, params.PostedAt).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return repo.db.QueryRow(query, args...).Scan(&params.ID)
+}
+
+func (repo *RepositoryPG) AddCandidates(candidates []post.Candidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	builder := sq.Insert("candidates").
+		Columns("batch", "topic", "format", "prompt_template", "texts", "heuristics", "heuristic", "judge", "judge_reason", "score", "selected")
+	for _, c := range candidates {
+		heuristics, err := json.Marshal(c.Heuristics)
+		if err != nil {
+			return err
+		}
+		builder = builder.Values(c.Batch, c.Topic, c.Format, c.PromptTemplate, pq.Array(c.Texts), heuristics, c.Heuristic, c.Judge, c.JudgeReason, c.Score, c.Selected)
+	}
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.db.Exec(query, args...)
+	return err
+}
+
+func (repo *RepositoryPG) AddSnapshots(snapshots []post.Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	builder := sq.Insert("tweet_metrics").
+		Columns("post_id", "tweet_id", "impressions", "likes", "reposts", "replies", "quotes", "bookmarks", "collected_at")
+	for _, s := range snapshots {
+		builder = builder.Values(s.PostID, s.TweetID, s.Impressions, s.Likes, s.Reposts, s.Replies, s.Quotes, s.Bookmarks, s.CollectedAt)
+	}
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
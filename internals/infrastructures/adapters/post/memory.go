@@ -0,0 +1,255 @@
+package post
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+)
+
+// MemoryRepository keeps posts, candidates and metric snapshots in memory
+// and sums performance the way the SQL queries do: only the latest
+// snapshot of every tweet counts
+type MemoryRepository struct {
+	mutex      sync.Mutex
+	nextID     int
+	posts      []post.Post
+	candidates []post.Candidate
+	snapshots  []post.Snapshot
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) AddPost(params *post.Post) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.nextID++
+	params.ID = repo.nextID
+	repo.posts = append(repo.posts, *params)
+	return nil
+}
+
+func (repo *MemoryRepository) AddCandidates(candidates []post.Candidate) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, c := range candidates {
+		c.ID = len(repo.candidates) + 1
+		c.CreatedAt = time.Now()
+		repo.candidates = append(repo.candidates, c)
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) GetPostsSince(since time.Time) ([]post.Post, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var posts []post.Post
+	for _, p := range repo.posts {
+		if !p.PostedAt.Before(since) {
+			posts = append(posts, p)
+		}
+	}
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].PostedAt.Before(posts[j].PostedAt) })
+	return posts, nil
+}
+
+func (repo *MemoryRepository) AddSnapshots(snapshots []post.Snapshot) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.snapshots = append(repo.snapshots, snapshots...)
+	return nil
+}
+
+func (repo *MemoryRepository) GetPerformance(params *post.GetPerformanceParams) ([]post.Performance, error) {
+	if _, ok := groupExpressions[params.GroupBy]; !ok {
+		return nil, fmt.Errorf("cannot group performance by %q", params.GroupBy)
+	}
+	location, err := loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	days := params.Days
+	if days == 0 {
+		days = defaultPerformanceDays
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	totals := repo.totals()
+	groups := map[string]*post.Performance{}
+	for _, p := range repo.recent(days) {
+		key := groupKey(&p, params.GroupBy, location)
+		group, ok := groups[key]
+		if !ok {
+			group = &post.Performance{Key: key}
+			groups[key] = group
+		}
+		group.Posts++
+		if t, ok := totals[p.ID]; ok {
+			group.Impressions += t.Impressions
+			group.Likes += t.Likes
+			group.Reposts += t.Reposts
+			group.Replies += t.Replies
+			group.Quotes += t.Quotes
+			group.Bookmarks += t.Bookmarks
+		}
+	}
+
+	performances := make([]post.Performance, 0, len(groups))
+	for _, group := range groups {
+		if group.Impressions > 0 {
+			engagements := group.Likes + group.Reposts + group.Replies + group.Quotes + group.Bookmarks
+			group.EngagementRate = float64(engagements) / float64(group.Impressions)
+		}
+		performances = append(performances, *group)
+	}
+	sort.Slice(performances, func(i, j int) bool { return performances[i].Key < performances[j].Key })
+	return performances, nil
+}
+
+func (repo *MemoryRepository) GetHourlyEngagement(params *post.GetSlotWeightsParams) ([]post.HourlyEngagement, error) {
+	weekday, ok := weekdays[params.Weekday]
+	if !ok {
+		return nil, fmt.Errorf("unknown weekday %q", params.Weekday)
+	}
+	location, err := loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	days := params.Days
+	if days == 0 {
+		days = defaultPerformanceDays
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Posts without metrics are left out, as in the inner join
+	totals := repo.totals()
+	hours := map[int]*post.HourlyEngagement{}
+	for _, p := range repo.recent(days) {
+		t, ok := totals[p.ID]
+		postedAt := p.PostedAt.In(location)
+		if !ok || int(postedAt.Weekday()) != weekday {
+			continue
+		}
+		hour, ok := hours[postedAt.Hour()]
+		if !ok {
+			hour = &post.HourlyEngagement{Hour: postedAt.Hour()}
+			hours[postedAt.Hour()] = hour
+		}
+		hour.Posts++
+		hour.Impressions += t.Impressions
+		hour.Engagements += t.Likes + t.Reposts + t.Replies + t.Quotes + t.Bookmarks
+	}
+
+	engagement := make([]post.HourlyEngagement, 0, len(hours))
+	for _, hour := range hours {
+		engagement = append(engagement, *hour)
+	}
+	sort.Slice(engagement, func(i, j int) bool { return engagement[i].Hour < engagement[j].Hour })
+	return engagement, nil
+}
+
+// VariantMetrics sums the latest engagement of every post an experiment's
+// variants produced, which the in-memory experiment store reads its
+// metrics from
+func (repo *MemoryRepository) VariantMetrics(experimentID int) []experiment.VariantMetrics {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	totals := repo.totals()
+	variants := map[int]*experiment.VariantMetrics{}
+	var order []int
+	for _, p := range repo.posts {
+		if p.ExperimentID == nil || *p.ExperimentID != experimentID || p.VariantID == nil {
+			continue
+		}
+		m, ok := variants[*p.VariantID]
+		if !ok {
+			m = &experiment.VariantMetrics{VariantID: *p.VariantID}
+			variants[*p.VariantID] = m
+			order = append(order, *p.VariantID)
+		}
+		m.Posts++
+		t := totals[p.ID]
+		m.Impressions += t.Impressions
+		m.Engagements += t.Likes + t.Reposts + t.Replies + t.Quotes + t.Bookmarks
+	}
+
+	metrics := make([]experiment.VariantMetrics, 0, len(order))
+	for _, id := range order {
+		metrics = append(metrics, *variants[id])
+	}
+	return metrics
+}
+
+// recent returns the posts made in the last number of days
+func (repo *MemoryRepository) recent(days int) []post.Post {
+	since := time.Now().AddDate(0, 0, -days)
+	var posts []post.Post
+	for _, p := range repo.posts {
+		if !p.PostedAt.Before(since) {
+			posts = append(posts, p)
+		}
+	}
+	return posts
+}
+
+// totals sums the latest snapshot of every tweet per post
+func (repo *MemoryRepository) totals() map[int]post.Snapshot {
+	latest := map[string]post.Snapshot{}
+	for _, s := range repo.snapshots {
+		if current, ok := latest[s.TweetID]; !ok || s.CollectedAt.After(current.CollectedAt) {
+			latest[s.TweetID] = s
+		}
+	}
+
+	totals := map[int]post.Snapshot{}
+	for _, s := range latest {
+		t := totals[s.PostID]
+		t.Impressions += s.Impressions
+		t.Likes += s.Likes
+		t.Reposts += s.Reposts
+		t.Replies += s.Replies
+		t.Quotes += s.Quotes
+		t.Bookmarks += s.Bookmarks
+		totals[s.PostID] = t
+	}
+	return totals
+}
+
+func groupKey(p *post.Post, groupBy string, location *time.Location) string {
+	switch groupBy {
+	case post.GroupByTopicType:
+		return p.TopicType
+	case post.GroupByCategory:
+		return p.Category
+	case post.GroupByFormat:
+		return p.Format
+	case post.GroupByPromptTemplate:
+		return p.PromptTemplate + ".v" + strconv.Itoa(p.PromptVersion)
+	case post.GroupByHour:
+		return strconv.Itoa(p.PostedAt.In(location).Hour())
+	default:
+		return strconv.Itoa(int(p.PostedAt.In(location).Weekday()))
+	}
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timezone)
+}
@@ -0,0 +1,184 @@
+package post
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/lib/pq"
+)
+
+const defaultPerformanceDays = 30
+
+var postColumns = []string{
+	"id", "topic", "topic_type", "category", "format", "prompt_template", "prompt_version", "experiment_id", "variant_id", "persona_id", "COALESCE(candidate_batch::TEXT, '')", "tweet_ids", "claims", "posted_at",
+}
+
+// groupExpressions whitelists what performance can be grouped by. Time based
+// groups read the timezone from the second query argument
+var groupExpressions = map[string]string{
+	post.GroupByTopicType:      "p.topic_type",
+	post.GroupByCategory:       "p.category",
+	post.GroupByFormat:         "p.format",
+	post.GroupByPromptTemplate: "p.prompt_template || '.v' || p.prompt_version",
+	post.GroupByHour:           "EXTRACT(HOUR FROM p.posted_at AT TIME ZONE $2)::TEXT",
+	post.GroupByWeekday:        "EXTRACT(DOW FROM p.posted_at AT TIME ZONE $2)::TEXT",
+}
+
+func (repo *RepositoryPG) GetPostsSince(since time.Time) ([]post.Post, error) {
+	query, args, err := sq.Select(postColumns...).From("posts").
+		Where(sq.GtOrEq{"posted_at": since}).
+		OrderBy("posted_at ASC").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []post.Post
+	for rows.Next() {
+		var p post.Post
+		var claims []byte
+		err = rows.Scan(&p.ID, &p.Topic, &p.TopicType, &p.Category, &p.Format, &p.PromptTemplate, &p.PromptVersion, &p.ExperimentID, &p.VariantID, &p.PersonaID, &p.CandidateBatch, pq.Array(&p.TweetIDs), &claims, &p.PostedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(claims, &p.Claims); err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	return posts, rows.Err()
+}
+
+func (repo *RepositoryPG) GetPerformance(params *post.GetPerformanceParams) ([]post.Performance, error) {
+	expression, ok := groupExpressions[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("cannot group performance by %q", params.GroupBy)
+	}
+	days := params.Days
+	if days == 0 {
+		days = defaultPerformanceDays
+	}
+	timezone := params.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	// Only the latest snapshot of each tweet counts, summed per post and
+	// then per group
+	query := fmt.Sprintf(`
+		WITH latest AS (
+			SELECT DISTINCT ON (tweet_id) post_id, impressions, likes, reposts, replies, quotes, bookmarks
+			FROM tweet_metrics
+			ORDER BY tweet_id, collected_at DESC
+		), totals AS (
+			SELECT post_id,
+				SUM(impressions) AS impressions, SUM(likes) AS likes, SUM(reposts) AS reposts,
+				SUM(replies) AS replies, SUM(quotes) AS quotes, SUM(bookmarks) AS bookmarks
+			FROM latest
+			GROUP BY post_id
+		)
+		SELECT %[1]s AS key,
+			COUNT(p.id),
+			COALESCE(SUM(t.impressions), 0),
+			COALESCE(SUM(t.likes), 0),
+			COALESCE(SUM(t.reposts), 0),
+			COALESCE(SUM(t.replies), 0),
+			COALESCE(SUM(t.quotes), 0),
+			COALESCE(SUM(t.bookmarks), 0)
+		FROM posts p
+		LEFT JOIN totals t ON t.post_id = p.id
+		WHERE p.posted_at >= NOW() - make_interval(days => $1)
+		GROUP BY %[1]s
+		ORDER BY key`, expression)
+
+	args := []interface{}{days}
+	if params.GroupBy == post.GroupByHour || params.GroupBy == post.GroupByWeekday {
+		args = append(args, timezone)
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var performances []post.Performance
+	for rows.Next() {
+		var p post.Performance
+		err = rows.Scan(&p.Key, &p.Posts, &p.Impressions, &p.Likes, &p.Reposts, &p.Replies, &p.Quotes, &p.Bookmarks)
+		if err != nil {
+			return nil, err
+		}
+		if p.Impressions > 0 {
+			engagements := p.Likes + p.Reposts + p.Replies + p.Quotes + p.Bookmarks
+			p.EngagementRate = float64(engagements) / float64(p.Impressions)
+		}
+		performances = append(performances, p)
+	}
+	return performances, rows.Err()
+}
+
+var weekdays = map[string]int{
+	"Sunday": 0, "Monday": 1, "Tuesday": 2, "Wednesday": 3, "Thursday": 4, "Friday": 5, "Saturday": 6,
+}
+
+func (repo *RepositoryPG) GetHourlyEngagement(params *post.GetSlotWeightsParams) ([]post.HourlyEngagement, error) {
+	weekday, ok := weekdays[params.Weekday]
+	if !ok {
+		return nil, fmt.Errorf("unknown weekday %q", params.Weekday)
+	}
+	days := params.Days
+	if days == 0 {
+		days = defaultPerformanceDays
+	}
+	timezone := params.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	query := `
+		WITH latest AS (
+			SELECT DISTINCT ON (tweet_id) post_id, impressions, likes + reposts + replies + quotes + bookmarks AS engagements
+			FROM tweet_metrics
+			ORDER BY tweet_id, collected_at DESC
+		), totals AS (
+			SELECT post_id, SUM(impressions) AS impressions, SUM(engagements) AS engagements
+			FROM latest
+			GROUP BY post_id
+		)
+		SELECT EXTRACT(HOUR FROM p.posted_at AT TIME ZONE $1)::INTEGER AS hour,
+			COUNT(p.id),
+			COALESCE(SUM(t.impressions), 0),
+			COALESCE(SUM(t.engagements), 0)
+		FROM posts p
+		JOIN totals t ON t.post_id = p.id
+		WHERE p.posted_at >= NOW() - make_interval(days => $2)
+			AND EXTRACT(DOW FROM p.posted_at AT TIME ZONE $1) = $3
+		GROUP BY hour
+		ORDER BY hour`
+
+	rows, err := repo.db.Query(query, timezone, days, weekday)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hours []post.HourlyEngagement
+	for rows.Next() {
+		var h post.HourlyEngagement
+		if err = rows.Scan(&h.Hour, &h.Posts, &h.Impressions, &h.Engagements); err != nil {
+			return nil, err
+		}
+		hours = append(hours, h)
+	}
+	return hours, rows.Err()
+}
@@ -0,0 +1,119 @@
+package project
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+var projectNotFoundErr = errors.New("project does not exist")
+
+type RepositoryPG struct {
+	db *sql.DB
+}
+
+func NewProjectRepositoryPG(db *sql.DB) project.Repository {
+	return &RepositoryPG{db: db}
+}
+
+func (repo *RepositoryPG) AddProject(params *project.Project) error {
+	query, args, err := sq.Insert("projects").
+		Columns("name", "category", "website", "x_handle", "status", "description").
+		Values(params.Name, params.Category, params.Website, params.XHandle, params.Status, params.Description).
+		Suffix("RETURNING id, created_at, updated_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return repo.db.QueryRow(query, args...).Scan(&params.ID, &params.CreatedAt, &params.UpdatedAt)
+}
+
+func (repo *RepositoryPG) ImportProjects(projects []project.Project) (int, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Re-importing a project keeps its id and when it was last featured
+	for _, p := range projects {
+		query, args, err := sq.Insert("projects").
+			Columns("name", "category", "website", "x_handle", "status", "description").
+			Values(p.Name, p.Category, p.Website, p.XHandle, p.Status, p.Description).
+			Suffix(`ON CONFLICT (name) DO UPDATE SET
+				category = EXCLUDED.category,
+				website = EXCLUDED.website,
+				x_handle = EXCLUDED.x_handle,
+				status = EXCLUDED.status,
+				description = EXCLUDED.description,
+				updated_at = CURRENT_TIMESTAMP`).
+			PlaceholderFormat(sq.Dollar).ToSql()
+		if err != nil {
+			return 0, err
+		}
+		if _, err = tx.Exec(query, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(projects), nil
+}
+
+func (repo *RepositoryPG) UpdateProject(params *project.Project) error {
+	query, args, err := sq.Update("projects").SetMap(map[string]interface{}{
+		"name":        params.Name,
+		"category":    params.Category,
+		"website":     params.Website,
+		"x_handle":    params.XHandle,
+		"status":      params.Status,
+		"description": params.Description,
+		"updated_at":  sq.Expr("CURRENT_TIMESTAMP"),
+	}).Where(sq.Eq{"id": params.ID}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return repo.execOne(query, args...)
+}
+
+func (repo *RepositoryPG) DeleteProject(id int) error {
+	query, args, err := sq.Delete("projects").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return repo.execOne(query, args...)
+}
+
+func (repo *RepositoryPG) MarkFeatured(id int, at time.Time) error {
+	query, args, err := sq.Update("projects").
+		Set("last_featured_at", at).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return repo.execOne(query, args...)
+}
+
+func (repo *RepositoryPG) execOne(query string, args ...interface{}) error {
+	result, err := repo.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return appError.NotFound(projectNotFoundErr)
+	}
+	return nil
+}
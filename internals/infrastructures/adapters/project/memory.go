@@ -0,0 +1,153 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// MemoryRepository keeps the project registry in memory, names unique as
+// in the projects table
+type MemoryRepository struct {
+	mutex    sync.Mutex
+	nextID   int
+	projects []*project.Project
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) AddProject(params *project.Project) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.named(params.Name) != nil {
+		return appError.Conflict(fmt.Errorf("project %q already exists", params.Name))
+	}
+	repo.add(params)
+	return nil
+}
+
+func (repo *MemoryRepository) ImportProjects(projects []project.Project) (int, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// Re-importing a project keeps its id and when it was last featured
+	for _, p := range projects {
+		existing := repo.named(p.Name)
+		if existing == nil {
+			repo.add(&p)
+			continue
+		}
+		existing.Category, existing.Website, existing.XHandle = p.Category, p.Website, p.XHandle
+		existing.Status, existing.Description = p.Status, p.Description
+		existing.UpdatedAt = time.Now()
+	}
+	return len(projects), nil
+}
+
+func (repo *MemoryRepository) GetProject(id int) (*project.Project, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	p := repo.find(id)
+	if p == nil {
+		return nil, appError.NotFound(projectNotFoundErr)
+	}
+	found := *p
+	return &found, nil
+}
+
+func (repo *MemoryRepository) GetProjects() ([]project.Project, error) {
+	return repo.getProjects(func(*project.Project) bool { return true }), nil
+}
+
+func (repo *MemoryRepository) GetFeaturable() ([]project.Project, error) {
+	return repo.getProjects(func(p *project.Project) bool { return p.Status != project.StatusSunset }), nil
+}
+
+func (repo *MemoryRepository) UpdateProject(params *project.Project) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	p := repo.find(params.ID)
+	if p == nil {
+		return appError.NotFound(projectNotFoundErr)
+	}
+	if named := repo.named(params.Name); named != nil && named.ID != params.ID {
+		return appError.Conflict(fmt.Errorf("project %q already exists", params.Name))
+	}
+	p.Name, p.Category, p.Website, p.XHandle = params.Name, params.Category, params.Website, params.XHandle
+	p.Status, p.Description = params.Status, params.Description
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (repo *MemoryRepository) DeleteProject(id int) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.find(id) == nil {
+		return appError.NotFound(projectNotFoundErr)
+	}
+	repo.projects = slices.DeleteFunc(repo.projects, func(p *project.Project) bool { return p.ID == id })
+	return nil
+}
+
+func (repo *MemoryRepository) MarkFeatured(id int, at time.Time) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	p := repo.find(id)
+	if p == nil {
+		return appError.NotFound(projectNotFoundErr)
+	}
+	p.LastFeaturedAt = &at
+	return nil
+}
+
+func (repo *MemoryRepository) getProjects(filter func(*project.Project) bool) []project.Project {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var projects []project.Project
+	for _, p := range repo.projects {
+		if filter(p) {
+			projects = append(projects, *p)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects
+}
+
+func (repo *MemoryRepository) add(params *project.Project) {
+	repo.nextID++
+	params.ID = repo.nextID
+	params.CreatedAt, params.UpdatedAt = time.Now(), time.Now()
+	added := *params
+	repo.projects = append(repo.projects, &added)
+}
+
+func (repo *MemoryRepository) find(id int) *project.Project {
+	for _, p := range repo.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (repo *MemoryRepository) named(name string) *project.Project {
+	for _, p := range repo.projects {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package project
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+var projectColumns = []string{
+	"id", "name", "category", "website", "x_handle", "status", "description", "last_featured_at", "created_at", "updated_at",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProject(row scanner) (*project.Project, error) {
+	var p project.Project
+	err := row.Scan(
+		&p.ID,
+		&p.Name,
+		&p.Category,
+		&p.Website,
+		&p.XHandle,
+		&p.Status,
+		&p.Description,
+		&p.LastFeaturedAt,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (repo *RepositoryPG) GetProject(id int) (*project.Project, error) {
+	query, args, err := sq.Select(projectColumns...).From("projects").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := scanProject(repo.db.QueryRow(query, args...))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, appError.NotFound(projectNotFoundErr)
+	case err != nil:
+		return nil, err
+	default:
+		return p, nil
+	}
+}
+
+func (repo *RepositoryPG) GetProjects() ([]project.Project, error) {
+	return repo.getProjects(sq.Select(projectColumns...).From("projects"))
+}
+
+func (repo *RepositoryPG) GetFeaturable() ([]project.Project, error) {
+	return repo.getProjects(sq.Select(projectColumns...).From("projects").
+		Where(sq.NotEq{"status": project.StatusSunset}))
+}
+
+func (repo *RepositoryPG) getProjects(builder sq.SelectBuilder) ([]project.Project, error) {
+	query, args, err := builder.OrderBy("name ASC").PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []project.Project
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *p)
+	}
+	return projects, rows.Err()
+}
@@ -0,0 +1,69 @@
+package prompt
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+)
+
+type OverridesPG struct {
+	db *sql.DB
+}
+
+func NewOverridesPG(db *sql.DB) prompt.OverrideRepository {
+	return &OverridesPG{db: db}
+}
+
+func (repo *OverridesPG) AddOverride(params *prompt.Template) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := sq.Update("prompt_templates").
+		Set("active", false).
+		Where(sq.Eq{"name": params.Name}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = sq.Insert("prompt_templates").
+		Columns("name", "version", "body", "active").
+		Values(params.Name, params.Version, params.Body, params.Active).
+		Suffix("RETURNING created_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if err = tx.QueryRow(query, args...).Scan(&params.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *OverridesPG) DeactivateOverrides(name string) error {
+	query, args, err := sq.Update("prompt_templates").
+		Set("active", false).
+		Where(sq.Eq{"name": name}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var statement *sql.Stmt
+	statement, err = repo.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(args...)
+	return err
+}
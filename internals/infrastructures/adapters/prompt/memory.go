@@ -0,0 +1,60 @@
+package prompt
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+)
+
+// OverridesMemory keeps template overrides in memory, so the registry
+// starts with the template files alone
+type OverridesMemory struct {
+	mutex     sync.Mutex
+	templates []prompt.Template
+}
+
+func NewOverridesMemory() *OverridesMemory {
+	return &OverridesMemory{}
+}
+
+func (repo *OverridesMemory) AddOverride(params *prompt.Template) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for i := range repo.templates {
+		if repo.templates[i].Name == params.Name {
+			repo.templates[i].Active = false
+		}
+	}
+	params.CreatedAt = time.Now()
+	repo.templates = append(repo.templates, *params)
+	return nil
+}
+
+func (repo *OverridesMemory) GetOverrides() ([]prompt.Template, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	templates := append([]prompt.Template(nil), repo.templates...)
+	sort.SliceStable(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].Version < templates[j].Version
+	})
+	return templates, nil
+}
+
+func (repo *OverridesMemory) DeactivateOverrides(name string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for i := range repo.templates {
+		if repo.templates[i].Name == name {
+			repo.templates[i].Active = false
+		}
+	}
+	return nil
+}
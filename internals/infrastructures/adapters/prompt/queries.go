@@ -0,0 +1,31 @@
+package prompt
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+)
+
+func (repo *OverridesPG) GetOverrides() ([]prompt.Template, error) {
+	query, args, err := sq.Select("name", "version", "body", "active", "created_at").From("prompt_templates").
+		OrderBy("name ASC", "version ASC").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []prompt.Template
+	for rows.Next() {
+		var t prompt.Template
+		if err = rows.Scan(&t.Name, &t.Version, &t.Body, &t.Active, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
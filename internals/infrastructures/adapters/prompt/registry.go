@@ -0,0 +1,242 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// Template files are named <name>.v<version>.tmpl
+var fileNamePattern = regexp.MustCompile(`^([a-z0-9_]+)\.v([0-9]+)\.tmpl$`)
+
+// sampleData renders every template once at load time so a template that
+// references a missing variable fails at startup instead of mid schedule
+var sampleData = []prompt.Data{
+	{},
+	{
+		Topic:   "sample topic",
+		Context: "sample context",
+		Product: "sample product",
+		Persona: "sample persona",
+		Limits:  prompt.Limits{MaxTweetLength: 280, TweetCharacters: 250, MaxThreadTweets: 3, MinPollOptions: 2, MaxPollOptions: 4, MaxPollOptionLength: 25},
+	},
+}
+
+type parsedTemplate struct {
+	prompt.Template
+	tmpl *template.Template
+}
+
+// Registry serves the active version of every template: the newest active
+// override from the database, or else the newest template file
+type Registry struct {
+	mutex     sync.RWMutex
+	files     map[string][]parsedTemplate
+	overrides map[string][]parsedTemplate
+	store     prompt.OverrideRepository
+}
+
+// NewPromptRepository loads the templates in dir, or the compiled in ones when
+// dir is empty, and panics if any of them fails to render
+func NewPromptRepository(dir string, store prompt.OverrideRepository) prompt.Repository {
+	files := DefaultTemplates()
+	if dir != "" {
+		files = os.DirFS(dir)
+	}
+
+	registry, err := NewRegistry(files, store)
+	if err != nil {
+		log.Panicf("failed to load prompt templates: %v", err)
+	}
+	return registry
+}
+
+func NewRegistry(files fs.FS, store prompt.OverrideRepository) (*Registry, error) {
+	registry := &Registry{
+		files:     map[string][]parsedTemplate{},
+		overrides: map[string][]parsedTemplate{},
+		store:     store,
+	}
+
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("template file %q is not named <name>.v<version>.tmpl", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[2])
+		body, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := parse(prompt.Template{Name: match[1], Version: version, Body: string(body), Source: prompt.SourceFile, Active: true})
+		if err != nil {
+			return nil, fmt.Errorf("template file %q: %v", entry.Name(), err)
+		}
+		registry.files[parsed.Name] = append(registry.files[parsed.Name], *parsed)
+	}
+	for name := range registry.files {
+		sortByVersion(registry.files[name])
+	}
+
+	overrides, err := store.GetOverrides()
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		if _, ok := registry.files[override.Name]; !ok {
+			continue
+		}
+		override.Source = prompt.SourceDatabase
+		parsed, err := parse(override)
+		if err != nil {
+			return nil, fmt.Errorf("template %s version %d: %v", override.Name, override.Version, err)
+		}
+		registry.overrides[parsed.Name] = append(registry.overrides[parsed.Name], *parsed)
+	}
+	for name := range registry.overrides {
+		sortByVersion(registry.overrides[name])
+	}
+
+	return registry, nil
+}
+
+func parse(t prompt.Template) (*parsedTemplate, error) {
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range sampleData {
+		var rendered strings.Builder
+		if err = tmpl.Execute(&rendered, data); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(rendered.String()) == "" {
+			return nil, errors.New("template renders an empty prompt")
+		}
+	}
+	return &parsedTemplate{Template: t, tmpl: tmpl}, nil
+}
+
+func sortByVersion(templates []parsedTemplate) {
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Version < templates[j].Version })
+}
+
+func (registry *Registry) active(name string) (*parsedTemplate, bool) {
+	if overrides := registry.overrides[name]; len(overrides) > 0 {
+		for i := len(overrides) - 1; i >= 0; i-- {
+			if overrides[i].Active {
+				return &overrides[i], true
+			}
+		}
+	}
+	if files := registry.files[name]; len(files) > 0 {
+		return &files[len(files)-1], true
+	}
+	return nil, false
+}
+
+func (registry *Registry) Render(name string, data prompt.Data) (*prompt.Rendered, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	t, ok := registry.active(name)
+	if !ok {
+		return nil, fmt.Errorf("template %q does not exist", name)
+	}
+	var rendered strings.Builder
+	if err := t.tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %q: %v", name, err)
+	}
+	return &prompt.Rendered{Name: t.Name, Version: t.Version, Text: rendered.String()}, nil
+}
+
+// GetTemplates lists every version of every template, marking the ones in use
+func (registry *Registry) GetTemplates() ([]prompt.Template, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	names := make([]string, 0, len(registry.files))
+	for name := range registry.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var templates []prompt.Template
+	for _, name := range names {
+		active, _ := registry.active(name)
+		versions := make([]parsedTemplate, 0, len(registry.files[name])+len(registry.overrides[name]))
+		versions = append(versions, registry.files[name]...)
+		versions = append(versions, registry.overrides[name]...)
+		for _, t := range versions {
+			t.Active = t.Source == active.Source && t.Version == active.Version
+			templates = append(templates, t.Template)
+		}
+	}
+	return templates, nil
+}
+
+func (registry *Registry) OverrideTemplate(name, body string) (*prompt.Template, error) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	files, ok := registry.files[name]
+	if !ok {
+		return nil, appError.NotFound(fmt.Errorf("template %q does not exist", name))
+	}
+
+	// Versions keep counting up across files and overrides, so a post's
+	// version always points at one body
+	version := files[len(files)-1].Version
+	if overrides := registry.overrides[name]; len(overrides) > 0 && overrides[len(overrides)-1].Version > version {
+		version = overrides[len(overrides)-1].Version
+	}
+
+	parsed, err := parse(prompt.Template{Name: name, Version: version + 1, Body: body, Source: prompt.SourceDatabase, Active: true})
+	if err != nil {
+		return nil, appError.BadRequest(err)
+	}
+	if err = registry.store.AddOverride(&parsed.Template); err != nil {
+		return nil, err
+	}
+
+	for i := range registry.overrides[name] {
+		registry.overrides[name][i].Active = false
+	}
+	registry.overrides[name] = append(registry.overrides[name], *parsed)
+	return &parsed.Template, nil
+}
+
+func (registry *Registry) ResetTemplate(name string) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, ok := registry.files[name]; !ok {
+		return appError.NotFound(fmt.Errorf("template %q does not exist", name))
+	}
+	if err := registry.store.DeactivateOverrides(name); err != nil {
+		return err
+	}
+	for i := range registry.overrides[name] {
+		registry.overrides[name][i].Active = false
+	}
+	return nil
+}
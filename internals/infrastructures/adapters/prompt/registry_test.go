@@ -0,0 +1,121 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/stretchr/testify/assert"
+)
+
+type overrideStub struct {
+	templates []prompt.Template
+}
+
+func (o *overrideStub) AddOverride(template *prompt.Template) error {
+	for i := range o.templates {
+		if o.templates[i].Name == template.Name {
+			o.templates[i].Active = false
+		}
+	}
+	o.templates = append(o.templates, *template)
+	return nil
+}
+
+func (o *overrideStub) GetOverrides() ([]prompt.Template, error) {
+	return o.templates, nil
+}
+
+func (o *overrideStub) DeactivateOverrides(name string) error {
+	for i := range o.templates {
+		if o.templates[i].Name == name {
+			o.templates[i].Active = false
+		}
+	}
+	return nil
+}
+
+func TestNewRegistry_DefaultTemplates(t *testing.T) {
+	registry, err := NewRegistry(DefaultTemplates(), &overrideStub{})
+	assert.NoError(t, err)
+
+	versions := map[string]int{
+		"product_list": 1, "product_topic": 2, "short_tweet": 2, "tweet_thread": 2, "poll": 2,
+		"user_centric": 1, "simplify_web3_jargon": 1, "blockchain_interoperability": 1, "web3_governance_daos": 1, "complex_concepts": 1,
+		"narratives_and_case_studies": 1, "developer_focused": 1, "myths_and_misconceptions": 1, "common_questions": 1, "emerging_trends": 1,
+	}
+	for name, version := range versions {
+		rendered, err := registry.Render(name, prompt.Data{Topic: "XCM", Product: "Moonbeam"})
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, version, rendered.Version, name)
+			assert.NotContains(t, rendered.Text, "{{", name)
+		}
+	}
+
+	rendered, _ := registry.Render("short_tweet", prompt.Data{Topic: "XCM", Context: "XCM v4 shipped", Persona: "## Persona: degen"})
+	assert.Contains(t, rendered.Text, "[CONTEXT: XCM v4 shipped]")
+	assert.Contains(t, rendered.Text, "## Persona: degen")
+}
+
+func TestNewRegistry_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"bad file name", fstest.MapFS{"short_tweet.tmpl": {Data: []byte("hi")}}},
+		{"parse error", fstest.MapFS{"short_tweet.v1.tmpl": {Data: []byte("{{if .Topic}}")}}},
+		{"unknown variable", fstest.MapFS{"short_tweet.v1.tmpl": {Data: []byte("{{.Audience}}")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(tt.files, &overrideStub{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRegistry_OverrideAndReset(t *testing.T) {
+	files := fstest.MapFS{
+		"short_tweet.v1.tmpl": {Data: []byte("v1 {{.Topic}}")},
+		"short_tweet.v2.tmpl": {Data: []byte("v2 {{.Topic}}")},
+	}
+	store := &overrideStub{}
+	registry, err := NewRegistry(files, store)
+	assert.NoError(t, err)
+
+	rendered, _ := registry.Render("short_tweet", prompt.Data{Topic: "XCM"})
+	assert.Equal(t, "v2 XCM", rendered.Text)
+
+	_, err = registry.OverrideTemplate("short_tweet", "{{.Nope}}")
+	assert.Error(t, err)
+	_, err = registry.OverrideTemplate("long_tweet", "{{.Topic}}")
+	assert.Error(t, err)
+
+	template, err := registry.OverrideTemplate("short_tweet", "v3 {{.Topic}}")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, template.Version)
+	rendered, _ = registry.Render("short_tweet", prompt.Data{Topic: "XCM"})
+	assert.Equal(t, 3, rendered.Version)
+
+	// Overrides survive a restart
+	reloaded, err := NewRegistry(files, store)
+	assert.NoError(t, err)
+	rendered, _ = reloaded.Render("short_tweet", prompt.Data{Topic: "XCM"})
+	assert.Equal(t, "v3 XCM", rendered.Text)
+
+	assert.NoError(t, reloaded.ResetTemplate("short_tweet"))
+	rendered, _ = reloaded.Render("short_tweet", prompt.Data{Topic: "XCM"})
+	assert.Equal(t, 2, rendered.Version)
+
+	templates, _ := reloaded.GetTemplates()
+	active := 0
+	for _, t := range templates {
+		if t.Active {
+			active++
+		}
+	}
+	assert.Len(t, templates, 3)
+	assert.Equal(t, 1, active)
+	assert.True(t, strings.HasPrefix(templates[1].Body, "v2"))
+}
@@ -0,0 +1,16 @@
+package prompt
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// DefaultTemplates are the template files compiled into the binary, used when
+// no template directory is configured
+func DefaultTemplates() fs.FS {
+	templates, _ := fs.Sub(defaultTemplates, "templates")
+	return templates
+}
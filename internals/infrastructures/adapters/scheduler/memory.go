@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
+)
+
+// MemoryStore keeps the scheduler's state in memory, so it is lost on
+// restart and not shared between instances
+type MemoryStore struct {
+	mutex     sync.Mutex
+	quotas    map[string]int
+	lastReset *time.Time
+	lastBeat  *time.Time
+	schedule  []scheduler.ScheduledTweet
+	usage     map[string]int
+	cursors   map[string]string
+	// expiries holds locks and cooldowns until they lapse
+	expiries map[string]time.Time
+	now      func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		quotas:   map[string]int{},
+		usage:    map[string]int{},
+		cursors:  map[string]string{},
+		expiries: map[string]time.Time{},
+		now:      time.Now,
+	}
+}
+
+func (store *MemoryStore) Quota(name string) (int, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	remaining, ok := store.quotas[name]
+	return remaining, ok, nil
+}
+
+func (store *MemoryStore) InitQuota(name string, remaining int) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.quotas[name]; !ok {
+		store.quotas[name] = remaining
+	}
+	return nil
+}
+
+func (store *MemoryStore) ResetQuotas(quotas map[string]int, at time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for name, remaining := range quotas {
+		store.quotas[name] = remaining
+	}
+	store.lastReset = &at
+	return nil
+}
+
+func (store *MemoryStore) LastReset() (*time.Time, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.lastReset, nil
+}
+
+func (store *MemoryStore) Beat(at time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.lastBeat = &at
+	return nil
+}
+
+func (store *MemoryStore) LastBeat() (*time.Time, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.lastBeat, nil
+}
+
+func (store *MemoryStore) ReserveQuota(name string, count int) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	remaining, ok := store.quotas[name]
+	if !ok || remaining < count {
+		return false, nil
+	}
+	store.quotas[name] = remaining - count
+	return true, nil
+}
+
+func (store *MemoryStore) GetSchedule() ([]scheduler.ScheduledTweet, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return append([]scheduler.ScheduledTweet(nil), store.schedule...), nil
+}
+
+func (store *MemoryStore) SetSchedule(schedule []scheduler.ScheduledTweet) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.schedule = append([]scheduler.ScheduledTweet(nil), schedule...)
+	return nil
+}
+
+// AddUsage keeps every day's count; a process lives nowhere near long
+// enough for them to matter
+func (store *MemoryStore) AddUsage(date string, count int) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.usage[date] += count
+	return nil
+}
+
+func (store *MemoryStore) AcquireLock(name string, ttl time.Duration) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.active("lock:" + name) {
+		return false, nil
+	}
+	store.expiries["lock:"+name] = store.now().Add(ttl)
+	return true, nil
+}
+
+func (store *MemoryStore) ReleaseLock(name string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.expiries, "lock:"+name)
+	return nil
+}
+
+func (store *MemoryStore) GetCursor(name string) (string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.cursors[name], nil
+}
+
+func (store *MemoryStore) SetCursor(name, value string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.cursors[name] = value
+	return nil
+}
+
+func (store *MemoryStore) StartCooldown(name string, ttl time.Duration) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.expiries[name] = store.now().Add(ttl)
+	return nil
+}
+
+func (store *MemoryStore) CoolingDown(name string) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.active(name), nil
+}
+
+// active reports whether a lock or cooldown is still held, dropping it once
+// it has lapsed. The caller holds the mutex
+func (store *MemoryStore) active(name string) bool {
+	expiresAt, ok := store.expiries[name]
+	if !ok {
+		return false
+	}
+	if !store.now().Before(expiresAt) {
+		delete(store.expiries, name)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_Quotas(t *testing.T) {
+	store := NewMemoryStore()
+
+	reserved, err := store.ReserveQuota(scheduler.QuotaTweets, 1)
+	assert.NoError(t, err)
+	assert.False(t, reserved, "a quota that was never set has nothing to reserve")
+
+	assert.NoError(t, store.InitQuota(scheduler.QuotaTweets, 3))
+	assert.NoError(t, store.InitQuota(scheduler.QuotaTweets, 10))
+
+	reserved, err = store.ReserveQuota(scheduler.QuotaTweets, 2)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = store.ReserveQuota(scheduler.QuotaTweets, 2)
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+
+	remaining, ok, err := store.Quota(scheduler.QuotaTweets)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, remaining)
+
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.ResetQuotas(map[string]int{scheduler.QuotaTweets: 17, scheduler.QuotaReplies: 5}, at))
+	remaining, _, _ = store.Quota(scheduler.QuotaTweets)
+	assert.Equal(t, 17, remaining)
+	lastReset, err := store.LastReset()
+	assert.NoError(t, err)
+	assert.Equal(t, at, *lastReset)
+}
+
+func TestMemoryStore_LocksAndCooldowns(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	acquired, err := store.AcquireLock("publish", 10*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, _ = store.AcquireLock("publish", 10*time.Second)
+	assert.False(t, acquired)
+
+	now = now.Add(10 * time.Second)
+	acquired, _ = store.AcquireLock("publish", 10*time.Second)
+	assert.True(t, acquired, "an expired lock can be taken again")
+	assert.NoError(t, store.ReleaseLock("publish"))
+	acquired, _ = store.AcquireLock("publish", 10*time.Second)
+	assert.True(t, acquired)
+
+	assert.NoError(t, store.StartCooldown("reply_cooldown:42", time.Hour))
+	cooling, err := store.CoolingDown("reply_cooldown:42")
+	assert.NoError(t, err)
+	assert.True(t, cooling)
+	now = now.Add(time.Hour)
+	cooling, _ = store.CoolingDown("reply_cooldown:42")
+	assert.False(t, cooling)
+}
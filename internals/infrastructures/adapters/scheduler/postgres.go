@@ -0,0 +1,234 @@
+package scheduler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
+)
+
+// live leaves out rows whose expiry has passed
+var live = sq.Or{sq.Eq{"expires_at": nil}, sq.Expr("expires_at > CURRENT_TIMESTAMP")}
+
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewSchedulerStorePG(db *sql.DB) scheduler.Store {
+	return &PostgresStore{db: db}
+}
+
+func (store *PostgresStore) get(key string) (string, bool, error) {
+	query, args, err := sq.Select("value").
+		From("scheduler_state").
+		Where(sq.And{sq.Eq{"key": key}, live}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return "", false, err
+	}
+
+	var value string
+	err = store.db.QueryRow(query, args...).Scan(&value)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return "", false, nil
+	case err != nil:
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// set upserts a value, expiring it after ttl unless ttl is zero
+func (store *PostgresStore) set(runner sq.BaseRunner, key, value string, ttl time.Duration) error {
+	var expiresAt *time.Time
+	if ttl > 0 {
+		at := time.Now().Add(ttl)
+		expiresAt = &at
+	}
+
+	_, err := sq.Insert("scheduler_state").
+		Columns("key", "value", "expires_at").
+		Values(key, value, expiresAt).
+		Suffix("ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, expires_at = EXCLUDED.expires_at").
+		PlaceholderFormat(sq.Dollar).
+		RunWith(runner).Exec()
+	return err
+}
+
+func (store *PostgresStore) Quota(name string) (int, bool, error) {
+	value, ok, err := store.get(keyPrefix + name)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+	remaining, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false, err
+	}
+	return remaining, true, nil
+}
+
+func (store *PostgresStore) InitQuota(name string, remaining int) error {
+	_, err := sq.Insert("scheduler_state").
+		Columns("key", "value").
+		Values(keyPrefix+name, strconv.Itoa(remaining)).
+		Suffix("ON CONFLICT (key) DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		RunWith(store.db).Exec()
+	return err
+}
+
+func (store *PostgresStore) ResetQuotas(quotas map[string]int, at time.Time) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for name, remaining := range quotas {
+		if err = store.set(tx, keyPrefix+name, strconv.Itoa(remaining), 0); err != nil {
+			return err
+		}
+	}
+	if err = store.set(tx, lastResetKey, at.Format(time.RFC3339), 0); err != nil {
+		return err
+	}
+
+	// Nothing reads expired rows, so clear them out once a day
+	_, err = sq.Delete("scheduler_state").
+		Where(sq.Expr("expires_at <= CURRENT_TIMESTAMP")).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(tx).Exec()
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (store *PostgresStore) LastReset() (*time.Time, error) {
+	return store.timestamp(lastResetKey)
+}
+
+func (store *PostgresStore) Beat(at time.Time) error {
+	return store.set(store.db, heartbeatKey, at.Format(time.RFC3339), 0)
+}
+
+func (store *PostgresStore) LastBeat() (*time.Time, error) {
+	return store.timestamp(heartbeatKey)
+}
+
+func (store *PostgresStore) timestamp(key string) (*time.Time, error) {
+	value, ok, err := store.get(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+	}
+	return &at, nil
+}
+
+func (store *PostgresStore) ReserveQuota(name string, count int) (bool, error) {
+	// The check and the decrement are one statement, so two instances can
+	// never spend the same capacity
+	result, err := sq.Update("scheduler_state").
+		Set("value", sq.Expr("(value::INT - ?)::TEXT", count)).
+		Where(sq.Eq{"key": keyPrefix + name}).
+		Where(sq.Expr("value::INT >= ?", count)).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(store.db).Exec()
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (store *PostgresStore) GetSchedule() ([]scheduler.ScheduledTweet, error) {
+	value, ok, err := store.get(scheduleKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var schedule []scheduler.ScheduledTweet
+	if err = json.Unmarshal([]byte(value), &schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (store *PostgresStore) SetSchedule(schedule []scheduler.ScheduledTweet) error {
+	value, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	return store.set(store.db, scheduleKey, string(value), 0)
+}
+
+func (store *PostgresStore) AddUsage(date string, count int) error {
+	_, err := sq.Insert("scheduler_state").
+		Columns("key", "value", "expires_at").
+		Values(keyPrefix+"usage_stats:"+date, strconv.Itoa(count), time.Now().Add(scheduler.UsageRetention)).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET
+			value = (CASE WHEN scheduler_state.expires_at <= CURRENT_TIMESTAMP THEN 0 ELSE scheduler_state.value::INT END + EXCLUDED.value::INT)::TEXT,
+			expires_at = EXCLUDED.expires_at`).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(store.db).Exec()
+	return err
+}
+
+func (store *PostgresStore) AcquireLock(name string, ttl time.Duration) (bool, error) {
+	// Take the lock if nobody holds it, or if whoever did let it expire
+	result, err := sq.Insert("scheduler_state").
+		Columns("key", "value", "expires_at").
+		Values(keyPrefix+"lock:"+name, "locked", time.Now().Add(ttl)).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, expires_at = EXCLUDED.expires_at
+			WHERE scheduler_state.expires_at <= CURRENT_TIMESTAMP`).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(store.db).Exec()
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (store *PostgresStore) ReleaseLock(name string) error {
+	_, err := sq.Delete("scheduler_state").
+		Where(sq.Eq{"key": keyPrefix + "lock:" + name}).
+		PlaceholderFormat(sq.Dollar).
+		RunWith(store.db).Exec()
+	return err
+}
+
+func (store *PostgresStore) GetCursor(name string) (string, error) {
+	value, _, err := store.get(keyPrefix + name)
+	return value, err
+}
+
+func (store *PostgresStore) SetCursor(name, value string) error {
+	return store.set(store.db, keyPrefix+name, value, 0)
+}
+
+func (store *PostgresStore) StartCooldown(name string, ttl time.Duration) error {
+	return store.set(store.db, keyPrefix+name, strconv.FormatInt(time.Now().Unix(), 10), ttl)
+}
+
+func (store *PostgresStore) CoolingDown(name string) (bool, error) {
+	_, ok, err := store.get(keyPrefix + name)
+	return ok, err
+}
@@ -0,0 +1,190 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces the scheduler's keys, unchanged from when the
+// scheduler kept them itself so state survives the upgrade
+const (
+	keyPrefix    = "twitter_scheduler:"
+	lastResetKey = keyPrefix + "last_reset"
+	scheduleKey  = keyPrefix + "schedule"
+	heartbeatKey = keyPrefix + "heartbeat"
+	retries      = 3
+)
+
+var errInsufficientQuota = errors.New("insufficient quota")
+
+type RedisStore struct {
+	redis *redis.Client
+}
+
+func NewSchedulerStoreRedis(redis *redis.Client) scheduler.Store {
+	return &RedisStore{redis: redis}
+}
+
+func (store *RedisStore) Quota(name string) (int, bool, error) {
+	remaining, err := store.redis.Get(context.Background(), keyPrefix+name).Int()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return 0, false, nil
+	case err != nil:
+		return 0, false, err
+	}
+	return remaining, true, nil
+}
+
+func (store *RedisStore) InitQuota(name string, remaining int) error {
+	return store.redis.SetNX(context.Background(), keyPrefix+name, remaining, 0).Err()
+}
+
+func (store *RedisStore) ResetQuotas(quotas map[string]int, at time.Time) error {
+	ctx := context.Background()
+	_, err := store.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for name, remaining := range quotas {
+			pipe.Set(ctx, keyPrefix+name, remaining, 0)
+		}
+		pipe.Set(ctx, lastResetKey, at.Format(time.RFC3339), 0)
+		return nil
+	})
+	return err
+}
+
+func (store *RedisStore) LastReset() (*time.Time, error) {
+	return store.timestamp(lastResetKey)
+}
+
+func (store *RedisStore) Beat(at time.Time) error {
+	return store.redis.Set(context.Background(), heartbeatKey, at.Format(time.RFC3339), 0).Err()
+}
+
+func (store *RedisStore) LastBeat() (*time.Time, error) {
+	return store.timestamp(heartbeatKey)
+}
+
+func (store *RedisStore) timestamp(key string) (*time.Time, error) {
+	value, err := store.redis.Get(context.Background(), key).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+	}
+	return &at, nil
+}
+
+func (store *RedisStore) ReserveQuota(name string, count int) (bool, error) {
+	ctx := context.Background()
+	key := keyPrefix + name
+	txf := func(tx *redis.Tx) error {
+		remaining, err := tx.Get(ctx, key).Int()
+		if err != nil {
+			return err
+		}
+		if remaining < count {
+			return errInsufficientQuota
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, remaining-count, 0)
+			return nil
+		})
+		return err
+	}
+
+	// Retry when another instance changed the quota under us
+	for range retries {
+		err := store.redis.Watch(ctx, txf, key)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, redis.TxFailedErr):
+			continue
+		case errors.Is(err, errInsufficientQuota), errors.Is(err, redis.Nil):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+	return false, fmt.Errorf("failed to reserve capacity after retries")
+}
+
+func (store *RedisStore) GetSchedule() ([]scheduler.ScheduledTweet, error) {
+	value, err := store.redis.Get(context.Background(), scheduleKey).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var schedule []scheduler.ScheduledTweet
+	if err = json.Unmarshal(value, &schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (store *RedisStore) SetSchedule(schedule []scheduler.ScheduledTweet) error {
+	value, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	return store.redis.Set(context.Background(), scheduleKey, value, 0).Err()
+}
+
+func (store *RedisStore) AddUsage(date string, count int) error {
+	ctx := context.Background()
+	key := keyPrefix + "usage_stats:" + date
+	_, err := store.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.IncrBy(ctx, key, int64(count))
+		pipe.Expire(ctx, key, scheduler.UsageRetention)
+		return nil
+	})
+	return err
+}
+
+func (store *RedisStore) AcquireLock(name string, ttl time.Duration) (bool, error) {
+	return store.redis.SetNX(context.Background(), keyPrefix+"lock:"+name, "locked", ttl).Result()
+}
+
+func (store *RedisStore) ReleaseLock(name string) error {
+	return store.redis.Del(context.Background(), keyPrefix+"lock:"+name).Err()
+}
+
+func (store *RedisStore) GetCursor(name string) (string, error) {
+	value, err := store.redis.Get(context.Background(), keyPrefix+name).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return value, err
+}
+
+func (store *RedisStore) SetCursor(name, value string) error {
+	return store.redis.Set(context.Background(), keyPrefix+name, value, 0).Err()
+}
+
+func (store *RedisStore) StartCooldown(name string, ttl time.Duration) error {
+	return store.redis.Set(context.Background(), keyPrefix+name, strconv.FormatInt(time.Now().Unix(), 10), ttl).Err()
+}
+
+func (store *RedisStore) CoolingDown(name string) (bool, error) {
+	exists, err := store.redis.Exists(context.Background(), keyPrefix+name).Result()
+	if err != nil {
+		return false, err
+	}
+	return exists > 0, nil
+}
@@ -0,0 +1,33 @@
+package sms
+
+import (
+	"sync"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/sms"
+)
+
+// MemoryRepository captures text messages in an outbox instead of sending them
+type MemoryRepository struct {
+	mutex  sync.Mutex
+	outbox []sms.MessageSMSParams
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{}
+}
+
+func (repo *MemoryRepository) SendSMS(params sms.MessageSMSParams) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.outbox = append(repo.outbox, params)
+	return nil
+}
+
+// Outbox returns every message sent so far, oldest first
+func (repo *MemoryRepository) Outbox() []sms.MessageSMSParams {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return append([]sms.MessageSMSParams(nil), repo.outbox...)
+}
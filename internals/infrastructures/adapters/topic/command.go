@@ -0,0 +1,139 @@
+package topic
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	embedding3 "github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	embedding2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+var topicNotFoundErr = errors.New("topic is not in the backlog")
+
+type RepositoryPG struct {
+	db           *sql.DB
+	vectorSearch *configs.VectorSearch
+	embeddings   *configs.Embeddings
+}
+
+func NewTopicRepositoryPG(db *sql.DB, vectorSearch *configs.VectorSearch, embeddings *configs.Embeddings) topic.Repository {
+	return &RepositoryPG{db: db, vectorSearch: vectorSearch, embeddings: embeddings}
+}
+
+func (repo *RepositoryPG) AddTopic(params *topic.Topic, embedding []float32) (bool, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Serialise inserts so two similar topics can't both pass the check
+	if _, err = tx.Exec("LOCK TABLE embeddings IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return false, err
+	}
+
+	model, _ := repo.embeddings.Active()
+	match, err := embedding2.Nearest(tx, repo.vectorSearch, model, embedding, embedding3.ContentTopic)
+	if err != nil {
+		return false, err
+	}
+	if match != nil && match.Similar {
+		return false, nil
+	}
+
+	embeddingID, err := embedding2.Insert(tx, model, embedding, params.Text, embedding3.ContentTopic, nil)
+	if err != nil {
+		return false, err
+	}
+
+	query, args, err := sq.Insert("topics").
+		Columns("text", "topic_type", "category", "source", "priority", "project_id", "embedding_id").
+		Values(params.Text, params.TopicType, params.Category, params.Source, params.Priority, params.ProjectID, embeddingID).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+	if err = tx.QueryRow(query, args...).Scan(&params.ID, &params.CreatedAt); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (repo *RepositoryPG) NextTopic(topicType, category, order string) (*topic.Topic, error) {
+	next := sq.Select("id").From("topics").
+		Where(queued(topicType, category)).
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+	if order == topic.OrderPriority {
+		next = next.OrderBy("priority DESC", "created_at ASC")
+	} else {
+		next = next.OrderBy("created_at ASC")
+	}
+	nextQuery, args, err := next.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sq.Update("topics").
+		Set("used_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where("id = ("+nextQuery+")", args...).
+		Suffix("RETURNING " + returningColumns).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := scanTopic(repo.db.QueryRow(query, args...))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return t, nil
+	}
+}
+
+func (repo *RepositoryPG) UpdatePriority(id, priority int) error {
+	query, args, err := sq.Update("topics").
+		Set("priority", priority).
+		Where(sq.Eq{"id": id, "used_at": nil}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return repo.execOne(query, args...)
+}
+
+// DeleteTopic removes a queued topic. Its embedding stays behind, so the
+// topic is not generated again
+func (repo *RepositoryPG) DeleteTopic(id int) error {
+	query, args, err := sq.Delete("topics").
+		Where(sq.Eq{"id": id, "used_at": nil}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	return repo.execOne(query, args...)
+}
+
+func (repo *RepositoryPG) execOne(query string, args ...interface{}) error {
+	result, err := repo.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return appError.NotFound(topicNotFoundErr)
+	}
+	return nil
+}
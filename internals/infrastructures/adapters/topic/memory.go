@@ -0,0 +1,138 @@
+package topic
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// MemoryRepository keeps the topic backlog in memory. Topics are deduped
+// against the embeddings kept with the other embedded values, as they are
+// in Postgres
+type MemoryRepository struct {
+	mutex      sync.Mutex
+	nextID     int
+	topics     []*topic.Topic
+	embeddings embedding.Repository
+}
+
+func NewMemoryRepository(embeddings embedding.Repository) *MemoryRepository {
+	return &MemoryRepository{embeddings: embeddings}
+}
+
+func (repo *MemoryRepository) AddTopic(params *topic.Topic, vector []float32) (bool, error) {
+	// Held across the check and the insert, so two similar topics can't both pass
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	match, err := repo.embeddings.Nearest(vector, embedding.ContentTopic)
+	if err != nil {
+		return false, err
+	}
+	if match != nil && match.Similar {
+		return false, nil
+	}
+	if _, err = repo.embeddings.AddEmbedding(vector, params.Text, embedding.ContentTopic, nil); err != nil {
+		return false, err
+	}
+
+	repo.nextID++
+	params.ID = repo.nextID
+	params.CreatedAt = time.Now()
+	added := *params
+	repo.topics = append(repo.topics, &added)
+	return true, nil
+}
+
+func (repo *MemoryRepository) NextTopic(topicType, category, order string) (*topic.Topic, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var next *topic.Topic
+	for _, t := range repo.topics {
+		if !isQueued(t, topicType, category) {
+			continue
+		}
+		// Topics are kept in the order they were created
+		if next == nil || (order == topic.OrderPriority && t.Priority > next.Priority) {
+			next = t
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	now := time.Now()
+	next.UsedAt = &now
+	used := *next
+	return &used, nil
+}
+
+func (repo *MemoryRepository) CountQueued(topicType, category string) (int, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	count := 0
+	for _, t := range repo.topics {
+		if isQueued(t, topicType, category) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetTopics returns the queued topics in the order they will be consumed by priority
+func (repo *MemoryRepository) GetTopics(params *topic.GetTopicsParams) ([]topic.Topic, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var topics []topic.Topic
+	for _, t := range repo.topics {
+		if t.UsedAt == nil && (params.TopicType == "" || t.TopicType == params.TopicType) {
+			topics = append(topics, *t)
+		}
+	}
+	sort.SliceStable(topics, func(i, j int) bool {
+		if topics[i].TopicType != topics[j].TopicType {
+			return topics[i].TopicType < topics[j].TopicType
+		}
+		return topics[i].Priority > topics[j].Priority
+	})
+	return topics, nil
+}
+
+func (repo *MemoryRepository) UpdatePriority(id, priority int) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, t := range repo.topics {
+		if t.ID == id && t.UsedAt == nil {
+			t.Priority = priority
+			return nil
+		}
+	}
+	return appError.NotFound(topicNotFoundErr)
+}
+
+// DeleteTopic removes a queued topic. Its embedding stays behind, so the
+// topic is not generated again
+func (repo *MemoryRepository) DeleteTopic(id int) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	index := slices.IndexFunc(repo.topics, func(t *topic.Topic) bool { return t.ID == id && t.UsedAt == nil })
+	if index < 0 {
+		return appError.NotFound(topicNotFoundErr)
+	}
+	repo.topics = slices.Delete(repo.topics, index, index+1)
+	return nil
+}
+
+// isQueued matches the queued topics of a type, and of a category unless it is empty
+func isQueued(t *topic.Topic, topicType, category string) bool {
+	return t.UsedAt == nil && t.TopicType == topicType && (category == "" || t.Category == category)
+}
@@ -0,0 +1,89 @@
+package topic
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+)
+
+var topicColumns = []string{
+	"id", "text", "topic_type", "category", "source", "priority", "project_id", "created_at", "used_at",
+}
+
+var returningColumns = strings.Join(topicColumns, ", ")
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTopic(row scanner) (*topic.Topic, error) {
+	var t topic.Topic
+	err := row.Scan(
+		&t.ID,
+		&t.Text,
+		&t.TopicType,
+		&t.Category,
+		&t.Source,
+		&t.Priority,
+		&t.ProjectID,
+		&t.CreatedAt,
+		&t.UsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// queued matches the queued topics of a type, and of a category unless it is empty
+func queued(topicType, category string) sq.Eq {
+	where := sq.Eq{"topic_type": topicType, "used_at": nil}
+	if category != "" {
+		where["category"] = category
+	}
+	return where
+}
+
+func (repo *RepositoryPG) CountQueued(topicType, category string) (int, error) {
+	query, args, err := sq.Select("COUNT(*)").From("topics").
+		Where(queued(topicType, category)).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = repo.db.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
+// GetTopics returns the queued topics in the order they will be consumed by priority
+func (repo *RepositoryPG) GetTopics(params *topic.GetTopicsParams) ([]topic.Topic, error) {
+	builder := sq.Select(topicColumns...).From("topics").
+		Where(sq.Eq{"used_at": nil}).
+		OrderBy("topic_type ASC", "priority DESC", "created_at ASC")
+	if params.TopicType != "" {
+		builder = builder.Where(sq.Eq{"topic_type": params.TopicType})
+	}
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var topics []topic.Topic
+	for rows.Next() {
+		t, err := scanTopic(rows)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, *t)
+	}
+	return topics, rows.Err()
+}
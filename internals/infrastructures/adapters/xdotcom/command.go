@@ -2,16 +2,23 @@ package xdotcom
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
 	"github.com/Pr3c10us/boilerplate/packages/configs"
 	"github.com/michimani/gotwi"
 	"github.com/michimani/gotwi/tweet/managetweet"
 	"github.com/michimani/gotwi/tweet/managetweet/types"
+	"github.com/michimani/gotwi/tweet/retweet"
+	retweetTypes "github.com/michimani/gotwi/tweet/retweet/types"
+	"github.com/michimani/gotwi/user/userlookup"
+	userLookupTypes "github.com/michimani/gotwi/user/userlookup/types"
 	"net/http"
 	"os"
 )
 
+const maxMediaPerTweet = 4
+
 type Repository struct {
 	environmentVariables *configs.EnvironmentVariables
 }
@@ -38,7 +45,26 @@ func (repo *Repository) Tweet(tweet xdotcom.Tweet) (string, error) {
 		return "", err
 	}
 
-	tweetId, err := tweet_g(client, tweet.Text, tweet.PreviousTweetID)
+	if len(tweet.Media) > maxMediaPerTweet {
+		return "", errors.New("a tweet can have at most 4 media attachments")
+	}
+	var mediaIDs []string
+	for _, media := range tweet.Media {
+		mediaID := media.ID
+		if mediaID == "" {
+			mediaID, err = uploadMedia(client, media)
+			if err != nil {
+				return "", err
+			}
+		}
+		mediaIDs = append(mediaIDs, mediaID)
+	}
+
+	if tweet.Poll != nil && len(mediaIDs) > 0 {
+		return "", errors.New("a tweet cannot have both a poll and media")
+	}
+
+	tweetId, err := tweet_g(client, tweet.Text, tweet.PreviousTweetID, tweet.QuoteTweetID, mediaIDs, tweet.Poll)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return "", err
@@ -57,7 +83,25 @@ func newOAuth1Client(accessToken, accessSecret string) (*gotwi.Client, error) {
 	return gotwi.NewClient(in)
 }
 
-func tweet_g(c *gotwi.Client, text, id string) (string, error) {
+func (repo *Repository) Retweet(tweetID string) error {
+	client, err := newOAuth1Client(repo.environmentVariables.XDotCom.AccessKey, repo.environmentVariables.XDotCom.AccessSecret)
+	if err != nil {
+		return err
+	}
+
+	me, err := userlookup.GetMe(context.Background(), client, &userLookupTypes.GetMeInput{})
+	if err != nil {
+		return err
+	}
+
+	_, err = retweet.Create(context.Background(), client, &retweetTypes.CreateInput{
+		ID:      gotwi.StringValue(me.Data.ID),
+		TweetID: tweetID,
+	})
+	return err
+}
+
+func tweet_g(c *gotwi.Client, text, id, quoteTweetID string, mediaIDs []string, poll *xdotcom.Poll) (string, error) {
 	var p *types.CreateInput
 	if id != "" {
 		p = &types.CreateInput{
@@ -72,6 +116,19 @@ func tweet_g(c *gotwi.Client, text, id string) (string, error) {
 		}
 	}
 
+	if len(mediaIDs) > 0 {
+		p.Media = &types.CreateInputMedia{MediaIDs: mediaIDs}
+	}
+	if quoteTweetID != "" {
+		p.QuoteTweetID = gotwi.String(quoteTweetID)
+	}
+	if poll != nil {
+		p.Poll = &types.CreateInputPoll{
+			DurationMinutes: gotwi.Int(poll.DurationMinutes),
+			Options:         poll.Options,
+		}
+	}
+
 	res, err := managetweet.Create(context.Background(), c, p)
 	if err != nil {
 		return "", err
@@ -0,0 +1,34 @@
+package xdotcom
+
+import (
+	"context"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/michimani/gotwi/user/userlookup"
+	userLookupTypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+// credentialsChecker verifies the access token by looking up the account
+// it belongs to
+type credentialsChecker struct {
+	environmentVariables *configs.EnvironmentVariables
+}
+
+func NewCredentialsChecker(environmentVariables *configs.EnvironmentVariables) health.Checker {
+	return &credentialsChecker{environmentVariables: environmentVariables}
+}
+
+func (checker *credentialsChecker) Name() string {
+	return "x"
+}
+
+func (checker *credentialsChecker) Check(ctx context.Context) error {
+	client, err := newOAuth1Client(checker.environmentVariables.XDotCom.AccessKey, checker.environmentVariables.XDotCom.AccessSecret)
+	if err != nil {
+		return err
+	}
+
+	_, err = userlookup.GetMe(ctx, client, &userLookupTypes.GetMeInput{})
+	return err
+}
@@ -0,0 +1,253 @@
+package xdotcom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/michimani/gotwi"
+)
+
+const (
+	mediaUploadEndpoint   = "https://upload.twitter.com/1.1/media/upload.json"
+	mediaMetadataEndpoint = "https://upload.twitter.com/1.1/media/metadata/create.json"
+	simpleUploadLimit     = 1024 * 1024
+	mediaChunkSize        = 1024 * 1024
+	maxAltTextLength      = 1000
+	maxStatusChecks       = 10
+)
+
+type mediaUploadResponse struct {
+	MediaIDString  string `json:"media_id_string"`
+	ProcessingInfo *struct {
+		State          string `json:"state"`
+		CheckAfterSecs int    `json:"check_after_secs"`
+		Error          *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"processing_info"`
+}
+
+func (repo *Repository) UploadMedia(media xdotcom.Media) (string, error) {
+	client, err := newOAuth1Client(repo.environmentVariables.XDotCom.AccessKey, repo.environmentVariables.XDotCom.AccessSecret)
+	if err != nil {
+		return "", err
+	}
+	return uploadMedia(client, media)
+}
+
+func uploadMedia(c *gotwi.Client, media xdotcom.Media) (string, error) {
+	if len(media.Data) == 0 {
+		return "", errors.New("media has no data")
+	}
+
+	var mediaID string
+	var err error
+	if len(media.Data) <= simpleUploadLimit {
+		mediaID, err = uploadSimple(c, media)
+	} else {
+		mediaID, err = uploadChunked(c, media)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if media.AltText != "" {
+		if err = createMediaMetadata(c, mediaID, media.AltText); err != nil {
+			return "", err
+		}
+	}
+	return mediaID, nil
+}
+
+func uploadSimple(c *gotwi.Client, media xdotcom.Media) (string, error) {
+	body, contentType, err := multipartMedia(media.Data)
+	if err != nil {
+		return "", err
+	}
+
+	var res mediaUploadResponse
+	if err = signedRequest(c, http.MethodPost, mediaUploadEndpoint, nil, body, contentType, &res); err != nil {
+		return "", err
+	}
+	return res.MediaIDString, nil
+}
+
+// uploadChunked runs the INIT, APPEND and FINALIZE commands for files too large for a single request
+func uploadChunked(c *gotwi.Client, media xdotcom.Media) (string, error) {
+	var initRes mediaUploadResponse
+	err := signedRequest(c, http.MethodPost, mediaUploadEndpoint, map[string]string{
+		"command":        "INIT",
+		"total_bytes":    strconv.Itoa(len(media.Data)),
+		"media_type":     media.MimeType,
+		"media_category": "tweet_image",
+	}, nil, "", &initRes)
+	if err != nil {
+		return "", fmt.Errorf("failed to init media upload: %v", err)
+	}
+	mediaID := initRes.MediaIDString
+
+	for segment, offset := 0, 0; offset < len(media.Data); segment, offset = segment+1, offset+mediaChunkSize {
+		end := offset + mediaChunkSize
+		if end > len(media.Data) {
+			end = len(media.Data)
+		}
+
+		body, contentType, err := multipartMedia(media.Data[offset:end])
+		if err != nil {
+			return "", err
+		}
+		err = signedRequest(c, http.MethodPost, mediaUploadEndpoint, map[string]string{
+			"command":       "APPEND",
+			"media_id":      mediaID,
+			"segment_index": strconv.Itoa(segment),
+		}, body, contentType, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to append media segment %d: %v", segment, err)
+		}
+	}
+
+	var finalizeRes mediaUploadResponse
+	err = signedRequest(c, http.MethodPost, mediaUploadEndpoint, map[string]string{
+		"command":  "FINALIZE",
+		"media_id": mediaID,
+	}, nil, "", &finalizeRes)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize media upload: %v", err)
+	}
+
+	// Wait for asynchronous processing to complete when X asks us to
+	status := finalizeRes
+	for i := 0; status.ProcessingInfo != nil && i < maxStatusChecks; i++ {
+		switch status.ProcessingInfo.State {
+		case "succeeded":
+			return mediaID, nil
+		case "failed":
+			if status.ProcessingInfo.Error != nil {
+				return "", fmt.Errorf("media processing failed: %s", status.ProcessingInfo.Error.Message)
+			}
+			return "", errors.New("media processing failed")
+		}
+
+		time.Sleep(time.Duration(status.ProcessingInfo.CheckAfterSecs) * time.Second)
+		status = mediaUploadResponse{}
+		err = signedRequest(c, http.MethodGet, mediaUploadEndpoint, map[string]string{
+			"command":  "STATUS",
+			"media_id": mediaID,
+		}, nil, "", &status)
+		if err != nil {
+			return "", fmt.Errorf("failed to check media status: %v", err)
+		}
+	}
+	if status.ProcessingInfo != nil && status.ProcessingInfo.State != "succeeded" {
+		return "", errors.New("media processing did not complete in time")
+	}
+
+	return mediaID, nil
+}
+
+func createMediaMetadata(c *gotwi.Client, mediaID, altText string) error {
+	runes := []rune(altText)
+	if len(runes) > maxAltTextLength {
+		altText = string(runes[:maxAltTextLength])
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"media_id": mediaID,
+		"alt_text": map[string]string{"text": altText},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = signedRequest(c, http.MethodPost, mediaMetadataEndpoint, nil, bytes.NewReader(payload), "application/json", nil); err != nil {
+		return fmt.Errorf("failed to set media alt text: %v", err)
+	}
+	return nil
+}
+
+func multipartMedia(data []byte) (io.Reader, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("media", "media")
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = part.Write(data); err != nil {
+		return nil, "", err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, writer.FormDataContentType(), nil
+}
+
+// signedRequest calls an X endpoint with an OAuth1 header. Only query parameters
+// are part of the signature, so request bodies are sent as-is.
+func signedRequest(c *gotwi.Client, method, endpoint string, params map[string]string, body io.Reader, contentType string, out interface{}) error {
+	query := url.Values{}
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	rawEndpoint := endpoint
+	if len(query) > 0 {
+		rawEndpoint = endpoint + "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, rawEndpoint, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	signature, err := gotwi.CreateOAuthSignature(&gotwi.CreateOAuthSignatureInput{
+		HTTPMethod:       method,
+		RawEndpoint:      rawEndpoint,
+		OAuthConsumerKey: c.OAuthConsumerKey(),
+		OAuthToken:       c.OAuthToken(),
+		SigningKey:       c.SigningKey(),
+		ParameterMap:     params,
+	})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(
+		`OAuth oauth_consumer_key="%s",oauth_nonce="%s",oauth_signature="%s",oauth_signature_method="%s",oauth_timestamp="%s",oauth_token="%s",oauth_version="%s"`,
+		url.QueryEscape(c.OAuthConsumerKey()),
+		url.QueryEscape(signature.OAuthNonce),
+		url.QueryEscape(signature.OAuthSignature),
+		url.QueryEscape(signature.OAuthSignatureMethod),
+		url.QueryEscape(signature.OAuthTimestamp),
+		url.QueryEscape(c.OAuthToken()),
+		url.QueryEscape(signature.OAuthVersion),
+	))
+
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		message, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("x api returned %d: %s", res.StatusCode, string(message))
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(res.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
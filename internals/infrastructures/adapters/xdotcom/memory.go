@@ -0,0 +1,155 @@
+package xdotcom
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+)
+
+// MemoryRepository is a local stand-in for X. It keeps everything it is asked to
+// post and serves timelines seeded through AddPost, so flows can run without credentials.
+type MemoryRepository struct {
+	mutex     sync.Mutex
+	nextID    int64
+	Tweets    map[string]xdotcom.Tweet
+	Media     map[string]xdotcom.Media
+	Retweets  []string
+	timelines map[string][]xdotcom.Post
+	mentions  []xdotcom.Post
+	metrics   map[string]xdotcom.PublicMetrics
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		nextID:    1000,
+		Tweets:    map[string]xdotcom.Tweet{},
+		Media:     map[string]xdotcom.Media{},
+		timelines: map[string][]xdotcom.Post{},
+		metrics:   map[string]xdotcom.PublicMetrics{},
+	}
+}
+
+func (repo *MemoryRepository) newID() string {
+	repo.nextID++
+	return strconv.FormatInt(repo.nextID, 10)
+}
+
+// AddPost puts a post on an account's timeline and returns its ID
+func (repo *MemoryRepository) AddPost(username, text string) string {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	post := xdotcom.Post{
+		ID:             repo.newID(),
+		AuthorID:       "user-" + username,
+		AuthorUsername: username,
+		Text:           text,
+		CreatedAt:      time.Now(),
+	}
+	repo.timelines[username] = append(repo.timelines[username], post)
+	return post.ID
+}
+
+// AddMention records a post by username that mentions the bot and returns its ID
+func (repo *MemoryRepository) AddMention(username, text string) string {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	post := xdotcom.Post{
+		ID:             repo.newID(),
+		AuthorID:       "user-" + username,
+		AuthorUsername: username,
+		Text:           text,
+		CreatedAt:      time.Now(),
+	}
+	repo.mentions = append(repo.mentions, post)
+	return post.ID
+}
+
+func (repo *MemoryRepository) Tweet(tweet xdotcom.Tweet) (string, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if tweet.Text == "" {
+		return "", errors.New("tweet text is empty")
+	}
+	id := repo.newID()
+	repo.Tweets[id] = tweet
+	return id, nil
+}
+
+func (repo *MemoryRepository) UploadMedia(media xdotcom.Media) (string, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if len(media.Data) == 0 {
+		return "", errors.New("media has no data")
+	}
+	id := repo.newID()
+	media.ID = id
+	repo.Media[id] = media
+	return id, nil
+}
+
+func (repo *MemoryRepository) Retweet(tweetID string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.Retweets = append(repo.Retweets, tweetID)
+	return nil
+}
+
+// UserTimeline returns posts newer than sinceID, newest first like the X API
+func (repo *MemoryRepository) UserTimeline(username string, sinceID string) ([]xdotcom.Post, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return newerThan(repo.timelines[username], sinceID), nil
+}
+
+func (repo *MemoryRepository) Mentions(sinceID string) ([]xdotcom.Post, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	return newerThan(repo.mentions, sinceID), nil
+}
+
+// SetMetrics sets the public metrics served for a tweet
+func (repo *MemoryRepository) SetMetrics(tweetID string, metrics xdotcom.PublicMetrics) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.metrics[tweetID] = metrics
+}
+
+// PublicMetrics serves metrics set through SetMetrics. Tweets this repository posted
+// without metrics report zeros, and unknown tweets are left out like deleted tweets on X.
+func (repo *MemoryRepository) PublicMetrics(tweetIDs []string) (map[string]xdotcom.PublicMetrics, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	metrics := map[string]xdotcom.PublicMetrics{}
+	for _, id := range tweetIDs {
+		if m, ok := repo.metrics[id]; ok {
+			metrics[id] = m
+		} else if _, ok = repo.Tweets[id]; ok {
+			metrics[id] = xdotcom.PublicMetrics{}
+		}
+	}
+	return metrics, nil
+}
+
+func newerThan(posts []xdotcom.Post, sinceID string) []xdotcom.Post {
+	since, _ := strconv.ParseInt(sinceID, 10, 64)
+	var newer []xdotcom.Post
+	for i := len(posts) - 1; i >= 0; i-- {
+		id, _ := strconv.ParseInt(posts[i].ID, 10, 64)
+		if id > since {
+			newer = append(newer, posts[i])
+		}
+	}
+	return newer
+}
@@ -0,0 +1,175 @@
+package xdotcom
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/michimani/gotwi"
+	"github.com/michimani/gotwi/fields"
+	"github.com/michimani/gotwi/resources"
+	"github.com/michimani/gotwi/tweet/timeline"
+	timelineTypes "github.com/michimani/gotwi/tweet/timeline/types"
+	"github.com/michimani/gotwi/user/userlookup"
+	userLookupTypes "github.com/michimani/gotwi/user/userlookup/types"
+)
+
+const (
+	timelinePageSize    = 100
+	tweetLookupEndpoint = "https://api.twitter.com/2/tweets"
+	tweetLookupPageSize = 100
+)
+
+type tweetLookupResponse struct {
+	Data []struct {
+		ID            string `json:"id"`
+		PublicMetrics struct {
+			ImpressionCount int `json:"impression_count"`
+			LikeCount       int `json:"like_count"`
+			RetweetCount    int `json:"retweet_count"`
+			ReplyCount      int `json:"reply_count"`
+			QuoteCount      int `json:"quote_count"`
+			BookmarkCount   int `json:"bookmark_count"`
+		} `json:"public_metrics"`
+	} `json:"data"`
+}
+
+func (repo *Repository) UserTimeline(username string, sinceID string) ([]xdotcom.Post, error) {
+	client, err := newOAuth1Client(repo.environmentVariables.XDotCom.AccessKey, repo.environmentVariables.XDotCom.AccessSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := userlookup.GetByUsername(context.Background(), client, &userLookupTypes.GetByUsernameInput{
+		Username: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	input := &timelineTypes.ListTweetsInput{
+		ID:          gotwi.StringValue(user.Data.ID),
+		SinceID:     sinceID,
+		Exclude:     fields.ExcludeList{fields.ExcludeReplies, fields.ExcludeRetweets},
+		TweetFields: fields.TweetFieldList{fields.TweetFieldCreatedAt, fields.TweetFieldAuthorID},
+		MaxResults:  timelinePageSize,
+	}
+	var posts []xdotcom.Post
+	for {
+		res, err := timeline.ListTweets(context.Background(), client, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, tweet := range res.Data {
+			post := toPost(tweet)
+			post.AuthorUsername = username
+			posts = append(posts, post)
+		}
+
+		if !morePages(sinceID, res.Meta.NextToken) {
+			return posts, nil
+		}
+		input.PaginationToken = *res.Meta.NextToken
+	}
+}
+
+// morePages reports whether to follow a page's next token. Everything after
+// a since ID is read, so nothing is skipped when the cursor moves past it.
+// Without one there is no gap to fill and the newest page will do
+func morePages(sinceID string, nextToken *string) bool {
+	return sinceID != "" && nextToken != nil && *nextToken != ""
+}
+
+func (repo *Repository) Mentions(sinceID string) ([]xdotcom.Post, error) {
+	client, err := newOAuth1Client(repo.environmentVariables.XDotCom.AccessKey, repo.environmentVariables.XDotCom.AccessSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := userlookup.GetMe(context.Background(), client, &userLookupTypes.GetMeInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	input := &timelineTypes.ListMentionsInput{
+		ID:          gotwi.StringValue(me.Data.ID),
+		SinceID:     sinceID,
+		Expansions:  fields.ExpansionList{fields.ExpansionAuthorID},
+		TweetFields: fields.TweetFieldList{fields.TweetFieldCreatedAt, fields.TweetFieldAuthorID},
+		UserFields:  fields.UserFieldList{fields.UserFieldUsername},
+		MaxResults:  timelinePageSize,
+	}
+	var posts []xdotcom.Post
+	for {
+		res, err := timeline.ListMentions(context.Background(), client, input)
+		if err != nil {
+			return nil, err
+		}
+
+		usernames := map[string]string{}
+		for _, user := range res.Includes.Users {
+			usernames[gotwi.StringValue(user.ID)] = gotwi.StringValue(user.Username)
+		}
+		for _, tweet := range res.Data {
+			post := toPost(tweet)
+			post.AuthorUsername = usernames[post.AuthorID]
+			posts = append(posts, post)
+		}
+
+		if !morePages(sinceID, res.Meta.NextToken) {
+			return posts, nil
+		}
+		input.PaginationToken = *res.Meta.NextToken
+	}
+}
+
+func toPost(tweet resources.Tweet) xdotcom.Post {
+	post := xdotcom.Post{
+		ID:       gotwi.StringValue(tweet.ID),
+		AuthorID: gotwi.StringValue(tweet.AuthorID),
+		Text:     gotwi.StringValue(tweet.Text),
+	}
+	if tweet.CreatedAt != nil {
+		post.CreatedAt = *tweet.CreatedAt
+	}
+	return post
+}
+
+// PublicMetrics looks tweets up in pages of 100. gotwi's tweet type has no impression or
+// bookmark counts, so the lookup is made with a signed request of our own.
+func (repo *Repository) PublicMetrics(tweetIDs []string) (map[string]xdotcom.PublicMetrics, error) {
+	client, err := newOAuth1Client(repo.environmentVariables.XDotCom.AccessKey, repo.environmentVariables.XDotCom.AccessSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := map[string]xdotcom.PublicMetrics{}
+	for start := 0; start < len(tweetIDs); start += tweetLookupPageSize {
+		end := start + tweetLookupPageSize
+		if end > len(tweetIDs) {
+			end = len(tweetIDs)
+		}
+
+		var res tweetLookupResponse
+		err = signedRequest(client, http.MethodGet, tweetLookupEndpoint, map[string]string{
+			"ids":          strings.Join(tweetIDs[start:end], ","),
+			"tweet.fields": "public_metrics",
+		}, nil, "", &res)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tweet := range res.Data {
+			metrics[tweet.ID] = xdotcom.PublicMetrics{
+				Impressions: tweet.PublicMetrics.ImpressionCount,
+				Likes:       tweet.PublicMetrics.LikeCount,
+				Reposts:     tweet.PublicMetrics.RetweetCount,
+				Replies:     tweet.PublicMetrics.ReplyCount,
+				Quotes:      tweet.PublicMetrics.QuoteCount,
+				Bookmarks:   tweet.PublicMetrics.BookmarkCount,
+			}
+		}
+	}
+	return metrics, nil
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/services/analytics"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services analytics.Services
+}
+
+func NewAnalyticsHandler(service analytics.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetPerformance(context *gin.Context) {
+	var params post.GetPerformanceParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	performance, err := handler.services.GetPerformance.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"performance": performance}, nil).Send(context)
+}
+
+func (handler *Handler) GetSlotWeights(context *gin.Context) {
+	var params post.GetSlotWeightsParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	weights, err := handler.services.GetSlotWeights.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"weights": weights}, nil).Send(context)
+}
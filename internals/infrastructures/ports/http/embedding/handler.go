@@ -0,0 +1,102 @@
+package embedding
+
+import (
+	embedding2 "github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/services/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services embedding.Services
+}
+
+func NewEmbeddingHandler(service embedding.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetMigration(context *gin.Context) {
+	migration, err := handler.services.GetMigration.Handle()
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"migration": migration}, nil).Send(context)
+}
+
+func (handler *Handler) StartMigration(context *gin.Context) {
+	var params embedding2.StartMigrationParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	migration, err := handler.services.StartMigration.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("embedding migration started", gin.H{"migration": migration}, nil).Send(context)
+}
+
+func (handler *Handler) CutOver(context *gin.Context) {
+	migration, err := handler.services.CutOver.Handle()
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("embeddings cut over to "+migration.Model, gin.H{"migration": migration}, nil).Send(context)
+}
+
+func (handler *Handler) CancelMigration(context *gin.Context) {
+	if err := handler.services.CancelMigration.Handle(); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("embedding migration cancelled", nil, nil).Send(context)
+}
+
+func (handler *Handler) Search(context *gin.Context) {
+	var params embedding2.SearchParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	results, err := handler.services.Search.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"results": results}, nil).Send(context)
+}
+
+func (handler *Handler) Unblock(context *gin.Context) {
+	var params embedding2.EmbeddingIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.Unblock.Handle(params.ID); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("embedding deleted", nil, nil).Send(context)
+}
+
+func (handler *Handler) GetCacheStats(context *gin.Context) {
+	response.NewSuccessResponse("", gin.H{"cache": handler.services.GetCacheStats.Handle()}, nil).Send(context)
+}
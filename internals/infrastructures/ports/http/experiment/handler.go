@@ -0,0 +1,102 @@
+package experiment
+
+import (
+	experiment2 "github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/services/experiment"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services experiment.Services
+}
+
+func NewExperimentHandler(service experiment.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) CreateExperiment(context *gin.Context) {
+	var params experiment2.CreateExperimentParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	e, err := handler.services.CreateExperiment.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("experiment started", gin.H{"experiment": e}, nil).Send(context)
+}
+
+func (handler *Handler) GetExperiments(context *gin.Context) {
+	experiments, err := handler.services.GetExperiments.Handle()
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"experiments": experiments}, nil).Send(context)
+}
+
+func (handler *Handler) GetResults(context *gin.Context) {
+	var params experiment2.ExperimentIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	e, results, err := handler.services.GetResults.Handle(params.ID)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"experiment": e, "results": results}, nil).Send(context)
+}
+
+func (handler *Handler) StopExperiment(context *gin.Context) {
+	var params experiment2.ExperimentIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.StopExperiment.Handle(params.ID); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("experiment stopped", nil, nil).Send(context)
+}
+
+func (handler *Handler) PromoteExperiment(context *gin.Context) {
+	var idParams experiment2.ExperimentIDParams
+	if err := context.ShouldBindUri(&idParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	var params experiment2.PromoteExperimentParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	params.ID = idParams.ID
+
+	if err := handler.services.PromoteExperiment.Handle(&params); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("variant promoted", nil, nil).Send(context)
+}
@@ -1,8 +1,22 @@
 package http
 
 import (
+	"context"
+	"errors"
 	authentication2 "github.com/Pr3c10us/boilerplate/internals/domains/authentication"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/analytics"
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/authentication"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/health"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/mention"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/persona"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/plan"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/policy"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/project"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/topic"
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http/tweet"
 	"github.com/Pr3c10us/boilerplate/internals/services"
 	"github.com/Pr3c10us/boilerplate/packages/configs"
@@ -13,6 +27,7 @@ import (
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/markbates/goth/gothic"
+	"net/http"
 )
 
 type GinServer struct {
@@ -20,6 +35,7 @@ type GinServer struct {
 	Engine      *gin.Engine
 	Logger      logger.Logger
 	Environment *configs.EnvironmentVariables
+	server      *http.Server
 }
 
 func NewGinServer(services *services.Services, logger logger.Logger, environment *configs.EnvironmentVariables) *GinServer {
@@ -29,6 +45,7 @@ func NewGinServer(services *services.Services, logger logger.Logger, environment
 		Logger:      logger,
 		Environment: environment,
 	}
+	ginServer.server = &http.Server{Addr: environment.Port, Handler: ginServer.Engine}
 
 	cookieStore := cookie.NewStore([]byte(environment.CookieSecret))
 	gothic.Store = cookieStore
@@ -43,6 +60,17 @@ func NewGinServer(services *services.Services, logger logger.Logger, environment
 	ginServer.SecureHealth()
 	ginServer.Authentication()
 	ginServer.Tweet()
+	ginServer.Knowledge()
+	ginServer.Mention()
+	ginServer.Analytics()
+	ginServer.Experiment()
+	ginServer.Prompt()
+	ginServer.Persona()
+	ginServer.Policy()
+	ginServer.Topic()
+	ginServer.Project()
+	ginServer.Plan()
+	ginServer.Embedding()
 
 	return ginServer
 }
@@ -58,6 +86,13 @@ func (server *GinServer) Health() {
 	server.Engine.GET("/health", func(c *gin.Context) {
 		response.NewSuccessResponse("server up!!!", nil, nil).Send(c)
 	})
+
+	handler := health.NewHealthHandler(server.Services.HealthService)
+	route := server.Engine.Group("/health")
+	{
+		route.GET("/live", handler.Live)
+		route.GET("/ready", handler.Ready)
+	}
 }
 
 func (server *GinServer) Authentication() {
@@ -84,9 +119,129 @@ func (server *GinServer) Tweet() {
 	}
 }
 
+func (server *GinServer) Knowledge() {
+	handler := knowledge.NewKnowledgeHandler(server.Services.KnowledgeService)
+	route := server.Engine.Group("/api/v1/knowledge", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.POST("/", handler.AddPassage)
+		route.GET("/search", handler.Search)
+	}
+}
+
+func (server *GinServer) Mention() {
+	handler := mention.NewMentionHandler(server.Services.MentionService)
+	route := server.Engine.Group("/api/v1/mentions", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/", handler.GetMentions)
+		route.POST("/:id/approve", handler.Approve)
+		route.POST("/:id/reject", handler.Reject)
+	}
+}
+
+func (server *GinServer) Analytics() {
+	handler := analytics.NewAnalyticsHandler(server.Services.AnalyticsService)
+	route := server.Engine.Group("/api/v1/analytics", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/performance", handler.GetPerformance)
+		route.GET("/slots", handler.GetSlotWeights)
+	}
+}
+
+func (server *GinServer) Experiment() {
+	handler := experiment.NewExperimentHandler(server.Services.ExperimentService)
+	route := server.Engine.Group("/api/v1/experiments", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.POST("/", handler.CreateExperiment)
+		route.GET("/", handler.GetExperiments)
+		route.GET("/:id", handler.GetResults)
+		route.POST("/:id/stop", handler.StopExperiment)
+		route.POST("/:id/promote", handler.PromoteExperiment)
+	}
+}
+
+func (server *GinServer) Prompt() {
+	handler := prompt.NewPromptHandler(server.Services.PromptService)
+	route := server.Engine.Group("/api/v1/prompts", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/", handler.GetTemplates)
+		route.POST("/:name", handler.OverrideTemplate)
+		route.POST("/:name/reset", handler.ResetTemplate)
+	}
+}
+
+func (server *GinServer) Persona() {
+	handler := persona.NewPersonaHandler(server.Services.PersonaService)
+	route := server.Engine.Group("/api/v1/personas", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/", handler.GetPersonas)
+		route.POST("/", handler.AddPersona)
+		route.PUT("/:id", handler.UpdatePersona)
+		route.DELETE("/:id", handler.DeletePersona)
+	}
+}
+
+func (server *GinServer) Policy() {
+	handler := policy.NewPolicyHandler(server.Services.PolicyService)
+	route := server.Engine.Group("/api/v1/policy", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/blocked", handler.GetBlocked)
+	}
+}
+
+func (server *GinServer) Topic() {
+	handler := topic.NewTopicHandler(server.Services.TopicService)
+	route := server.Engine.Group("/api/v1/topics", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/", handler.GetTopics)
+		route.POST("/", handler.AddTopic)
+		route.PUT("/:id/priority", handler.UpdatePriority)
+		route.DELETE("/:id", handler.DeleteTopic)
+	}
+}
+
+func (server *GinServer) Project() {
+	handler := project.NewProjectHandler(server.Services.ProjectService)
+	route := server.Engine.Group("/api/v1/projects", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/", handler.GetProjects)
+		route.POST("/", handler.AddProject)
+		route.POST("/import", handler.ImportProjects)
+		route.PUT("/:id", handler.UpdateProject)
+		route.DELETE("/:id", handler.DeleteProject)
+	}
+}
+
+func (server *GinServer) Plan() {
+	handler := plan.NewPlanHandler(server.Services.PlanService)
+	route := server.Engine.Group("/api/v1/plan", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/", handler.GetPlan)
+	}
+}
+
+func (server *GinServer) Embedding() {
+	handler := embedding.NewEmbeddingHandler(server.Services.EmbeddingService)
+	route := server.Engine.Group("/api/v1/embeddings", middlewares.UserAuthorizationMiddleware(server.Services.AuthenticationServices, server.Environment))
+	{
+		route.GET("/search", handler.Search)
+		route.GET("/cache", handler.GetCacheStats)
+		route.DELETE("/:id", handler.Unblock)
+		route.GET("/migration", handler.GetMigration)
+		route.POST("/migration", handler.StartMigration)
+		route.POST("/migration/cutover", handler.CutOver)
+		route.DELETE("/migration", handler.CancelMigration)
+	}
+}
+
 func (server *GinServer) Run() {
-	err := server.Engine.Run(server.Environment.Port)
-	if err != nil {
+	err := server.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		server.Logger.Log("panic", "failed to start server")
 	}
 }
+
+// Shutdown stops accepting requests and waits for the ones in flight to
+// finish, until ctx is done
+func (server *GinServer) Shutdown(ctx context.Context) error {
+	return server.server.Shutdown(ctx)
+}
@@ -0,0 +1,41 @@
+package health
+
+import (
+	"net/http"
+
+	health2 "github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/internals/services/health"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services health.Services
+}
+
+func NewHealthHandler(service health.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+// Live only says the process is serving requests, so a broken dependency
+// never gets it restarted
+func (handler *Handler) Live(context *gin.Context) {
+	response.NewSuccessResponse("server up!!!", gin.H{"status": health2.StatusUp}, nil).Send(context)
+}
+
+// Ready is 503 while any dependency is down, with the state of each
+func (handler *Handler) Ready(context *gin.Context) {
+	report := handler.services.Ready.Handle()
+	if report.Status != health2.StatusUp {
+		response.ErrorResponse{
+			StatusCode:   http.StatusServiceUnavailable,
+			Message:      "Service Unavailable",
+			ErrorMessage: report,
+		}.Send(context)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"health": report}, nil).Send(context)
+}
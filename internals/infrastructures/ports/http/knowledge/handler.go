@@ -0,0 +1,52 @@
+package knowledge
+
+import (
+	knowledge2 "github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/services/knowledge"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services knowledge.Services
+}
+
+func NewKnowledgeHandler(service knowledge.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) AddPassage(context *gin.Context) {
+	var params knowledge2.AddPassageParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.AddPassage.Handle(&params); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("passage added", nil, nil).Send(context)
+}
+
+func (handler *Handler) Search(context *gin.Context) {
+	var params knowledge2.SearchParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	passages, err := handler.services.Search.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"passages": passages}, nil).Send(context)
+}
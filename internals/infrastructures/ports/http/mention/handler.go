@@ -0,0 +1,75 @@
+package mention
+
+import (
+	mention2 "github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/internals/services/mention"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services mention.Services
+}
+
+func NewMentionHandler(service mention.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetMentions(context *gin.Context) {
+	var params mention2.GetMentionsParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	mentions, err := handler.services.GetMentions.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"mentions": mentions}, nil).Send(context)
+}
+
+func (handler *Handler) Approve(context *gin.Context) {
+	var idParams mention2.MentionIDParams
+	if err := context.ShouldBindUri(&idParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	var params mention2.ApproveMentionParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	params.ID = idParams.ID
+
+	if err := handler.services.Approve.Handle(&params); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("reply approved", nil, nil).Send(context)
+}
+
+func (handler *Handler) Reject(context *gin.Context) {
+	var params mention2.MentionIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.Reject.Handle(params.ID); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("reply rejected", nil, nil).Send(context)
+}
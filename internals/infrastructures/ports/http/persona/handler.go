@@ -0,0 +1,86 @@
+package persona
+
+import (
+	persona2 "github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/services/persona"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services persona.Services
+}
+
+func NewPersonaHandler(service persona.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetPersonas(context *gin.Context) {
+	personas, err := handler.services.GetPersonas.Handle()
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"personas": personas}, nil).Send(context)
+}
+
+func (handler *Handler) AddPersona(context *gin.Context) {
+	var params persona2.PersonaParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	p, err := handler.services.AddPersona.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("persona added", gin.H{"persona": p}, nil).Send(context)
+}
+
+func (handler *Handler) UpdatePersona(context *gin.Context) {
+	var idParams persona2.PersonaIDParams
+	if err := context.ShouldBindUri(&idParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	var params persona2.UpdatePersonaParams
+	if err := context.ShouldBind(&params.PersonaParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	params.ID = idParams.ID
+
+	p, err := handler.services.UpdatePersona.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("persona updated", gin.H{"persona": p}, nil).Send(context)
+}
+
+func (handler *Handler) DeletePersona(context *gin.Context) {
+	var params persona2.PersonaIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.DeletePersona.Handle(params.ID); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("persona deleted", nil, nil).Send(context)
+}
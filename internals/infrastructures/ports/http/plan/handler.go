@@ -0,0 +1,36 @@
+package plan
+
+import (
+	plan2 "github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/internals/services/plan"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services plan.Services
+}
+
+func NewPlanHandler(service plan.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetPlan(context *gin.Context) {
+	var params plan2.GetPlanParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	p, err := handler.services.GetPlan.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"plan": p}, nil).Send(context)
+}
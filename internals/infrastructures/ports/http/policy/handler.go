@@ -0,0 +1,36 @@
+package policy
+
+import (
+	policy2 "github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/services/policy"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services policy.Services
+}
+
+func NewPolicyHandler(service policy.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetBlocked(context *gin.Context) {
+	var params policy2.GetBlockedParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	blocked, err := handler.services.GetBlocked.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"blocked": blocked}, nil).Send(context)
+}
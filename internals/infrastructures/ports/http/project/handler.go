@@ -0,0 +1,115 @@
+package project
+
+import (
+	"errors"
+	"io"
+
+	project2 "github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/services/project"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services project.Services
+}
+
+func NewProjectHandler(service project.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetProjects(context *gin.Context) {
+	projects, err := handler.services.GetProjects.Handle()
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"projects": projects}, nil).Send(context)
+}
+
+func (handler *Handler) AddProject(context *gin.Context) {
+	var params project2.ProjectParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	p, err := handler.services.AddProject.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("project added", gin.H{"project": p}, nil).Send(context)
+}
+
+// ImportProjects takes a CSV file either as the "file" form field or as the raw request body
+func (handler *Handler) ImportProjects(context *gin.Context) {
+	var file io.Reader = context.Request.Body
+	if header, err := context.FormFile("file"); err == nil {
+		opened, err := header.Open()
+		if err != nil {
+			_ = context.Error(appError.BadRequest(err))
+			return
+		}
+		defer opened.Close()
+		file = opened
+	} else if context.ContentType() == "multipart/form-data" {
+		_ = context.Error(appError.BadRequest(errors.New("file is required")))
+		return
+	}
+
+	imported, err := handler.services.ImportProjects.Handle(file)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("projects imported", gin.H{"imported": imported}, nil).Send(context)
+}
+
+func (handler *Handler) UpdateProject(context *gin.Context) {
+	var idParams project2.ProjectIDParams
+	if err := context.ShouldBindUri(&idParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	var params project2.UpdateProjectParams
+	if err := context.ShouldBind(&params.ProjectParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	params.ID = idParams.ID
+
+	p, err := handler.services.UpdateProject.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("project updated", gin.H{"project": p}, nil).Send(context)
+}
+
+func (handler *Handler) DeleteProject(context *gin.Context) {
+	var params project2.ProjectIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.DeleteProject.Handle(params.ID); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("project deleted", nil, nil).Send(context)
+}
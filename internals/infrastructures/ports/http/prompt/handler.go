@@ -0,0 +1,69 @@
+package prompt
+
+import (
+	prompt2 "github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/services/prompt"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services prompt.Services
+}
+
+func NewPromptHandler(service prompt.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetTemplates(context *gin.Context) {
+	templates, err := handler.services.GetTemplates.Handle()
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"templates": templates}, nil).Send(context)
+}
+
+func (handler *Handler) OverrideTemplate(context *gin.Context) {
+	var nameParams prompt2.TemplateNameParams
+	if err := context.ShouldBindUri(&nameParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	var params prompt2.OverrideTemplateParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	params.Name = nameParams.Name
+
+	template, err := handler.services.OverrideTemplate.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("template updated", gin.H{"template": template}, nil).Send(context)
+}
+
+func (handler *Handler) ResetTemplate(context *gin.Context) {
+	var params prompt2.TemplateNameParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.ResetTemplate.Handle(params.Name); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("template reset", nil, nil).Send(context)
+}
@@ -0,0 +1,92 @@
+package topic
+
+import (
+	topic2 "github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	"github.com/Pr3c10us/boilerplate/internals/services/topic"
+	"github.com/Pr3c10us/boilerplate/packages/response"
+	"github.com/Pr3c10us/boilerplate/packages/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services topic.Services
+}
+
+func NewTopicHandler(service topic.Services) Handler {
+	return Handler{
+		services: service,
+	}
+}
+
+func (handler *Handler) GetTopics(context *gin.Context) {
+	var params topic2.GetTopicsParams
+	if err := context.ShouldBindQuery(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	topics, err := handler.services.GetTopics.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("", gin.H{"topics": topics}, nil).Send(context)
+}
+
+func (handler *Handler) AddTopic(context *gin.Context) {
+	var params topic2.AddTopicParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	t, err := handler.services.AddTopic.Handle(&params)
+	if err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("topic added", gin.H{"topic": t}, nil).Send(context)
+}
+
+func (handler *Handler) UpdatePriority(context *gin.Context) {
+	var idParams topic2.TopicIDParams
+	if err := context.ShouldBindUri(&idParams); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	var params topic2.UpdatePriorityParams
+	if err := context.ShouldBind(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+	params.ID = idParams.ID
+
+	if err := handler.services.UpdatePriority.Handle(&params); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("topic updated", nil, nil).Send(context)
+}
+
+func (handler *Handler) DeleteTopic(context *gin.Context) {
+	var params topic2.TopicIDParams
+	if err := context.ShouldBindUri(&params); err != nil {
+		err = validator.ValidateRequest(err)
+		_ = context.Error(err)
+		return
+	}
+
+	if err := handler.services.DeleteTopic.Handle(params.ID); err != nil {
+		_ = context.Error(err)
+		return
+	}
+
+	response.NewSuccessResponse("topic deleted", nil, nil).Send(context)
+}
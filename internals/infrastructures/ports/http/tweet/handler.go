@@ -25,12 +25,12 @@ func NewTweetHandler(service tweet.Services, environmentVariables *configs.Envir
 }
 
 func (handler *Handler) Topic(context *gin.Context) {
-	tweets, _, err := handler.services.Tweet.Tweets()
+	draft, _, err := handler.services.Tweet.Tweets(nil)
 	if err != nil {
 		_ = context.Error(err)
 		//fmt.Println(err)
 		return
 	}
 
-	response.NewSuccessResponse("", gin.H{"tweets": tweets}, nil).Send(context)
+	response.NewSuccessResponse("", gin.H{"draft": draft}, nil).Send(context)
 }
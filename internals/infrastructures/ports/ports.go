@@ -1,6 +1,7 @@
 package ports
 
 import (
+	scheduler2 "github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/http"
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/ports/scheduler"
 	"github.com/Pr3c10us/boilerplate/internals/services"
@@ -13,9 +14,13 @@ type Ports struct {
 	Scheduler *scheduler.Scheduler
 }
 
-func NewPorts(services *services.Services, logger logger.Logger, environment *configs.EnvironmentVariables) *Ports {
+func NewPorts(services *services.Services, store scheduler2.Store, logger logger.Logger, environment *configs.EnvironmentVariables) (*Ports, error) {
+	newScheduler, err := scheduler.NewScheduler(services, store, environment)
+	if err != nil {
+		return nil, err
+	}
 	return &Ports{
 		GinServer: http.NewGinServer(services, logger, environment),
-		Scheduler: scheduler.NewScheduler(services, environment),
-	}
+		Scheduler: newScheduler,
+	}, nil
 }
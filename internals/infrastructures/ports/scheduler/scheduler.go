@@ -2,23 +2,23 @@ package scheduler
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/scheduler"
 	"github.com/Pr3c10us/boilerplate/internals/services"
+	"github.com/Pr3c10us/boilerplate/internals/services/tweet/command"
 	"github.com/Pr3c10us/boilerplate/packages/configs"
 	"log"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
-
-	"github.com/go-redis/redis/v8"
 )
 
 const (
 	DailyTweetLimit = 17
-	RedisKeyPrefix  = "twitter_scheduler:"
 	LockTimeout     = 10 * time.Second
 )
 
@@ -54,30 +54,28 @@ func (s *Scheduler) calculateDailyDistribution() ([]TweetDistribution, error) {
 	daySchedule := WeeklySchedule[now.Weekday().String()]
 
 	// Get remaining tweets for today
-	quotaKey := RedisKeyPrefix + "daily_quota"
-	remainingTweets, err := s.rdb.Get(s.ctx, quotaKey).Int()
+	remainingTweets, _, err := s.store.Quota(scheduler.QuotaTweets)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get remaining tweets: %v", err)
 	}
 
-	totalHours := daySchedule.TotalHours()
-	var distributions []TweetDistribution
-
-	// Calculate tweets per hour, distributing remaining tweets across available hours
-	tweetsPerHour := float64(remainingTweets) / float64(totalHours)
-
-	for _, window := range daySchedule.Windows {
-		// Calculate tweets for this window based on its duration
-		windowDuration := window.Duration()
-		windowTweets := int(math.Round(tweetsPerHour * float64(windowDuration)))
+	// Bias towards the hours that engaged best, falling back to an even
+	// spread when nothing can be learnt
+	weights, err := s.hourWeights(now.Weekday())
+	if err != nil {
+		log.Printf("Error getting posting time weights: %v", err)
+	}
 
-		if windowTweets > 0 {
+	var distributions []TweetDistribution
+	counts := allocateTweets(daySchedule.Windows, weights, remainingTweets, s.environment.PostingTimes.MinTweetsPerWindow, s.environment.PostingTimes.MaxTweetsPerWindow)
+	for i, window := range daySchedule.Windows {
+		if counts[i] > 0 {
 			// Generate posting times within the window
-			intervals := generatePostingTimes(window, windowTweets, now)
+			intervals := generatePostingTimes(window, counts[i], now, weights)
 
 			distributions = append(distributions, TweetDistribution{
 				Window:     window,
-				TweetCount: windowTweets,
+				TweetCount: counts[i],
 				Intervals:  intervals,
 			})
 		}
@@ -86,7 +84,85 @@ func (s *Scheduler) calculateDailyDistribution() ([]TweetDistribution, error) {
 	return distributions, nil
 }
 
-func generatePostingTimes(window PostingWindow, tweetCount int, today time.Time) []time.Time {
+// hourWeights returns the learnt weight of every hour of the weekday, or nil
+// while no post made on that weekday has metrics yet
+func (s *Scheduler) hourWeights(weekday time.Weekday) (map[int]float64, error) {
+	slots, err := s.services.AnalyticsService.GetSlotWeights.Handle(&post.GetSlotWeightsParams{Weekday: weekday.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	posts := 0
+	weights := make(map[int]float64, len(slots))
+	for _, slot := range slots {
+		weights[slot.Hour] = slot.Weight
+		posts += slot.Posts
+	}
+	if posts == 0 {
+		return nil, nil
+	}
+	return weights, nil
+}
+
+func windowWeight(window PostingWindow, weights map[int]float64) float64 {
+	if weights == nil {
+		return float64(window.Duration())
+	}
+	var weight float64
+	for hour := window.StartHour; hour <= window.EndHour; hour++ {
+		weight += weights[hour]
+	}
+	return weight
+}
+
+// allocateTweets splits the day's tweets across windows in proportion to the
+// weight of their hours, keeping every window between floor and ceiling
+func allocateTweets(windows []PostingWindow, weights map[int]float64, total, floor, ceiling int) []int {
+	counts := make([]int, len(windows))
+	targets := make([]float64, len(windows))
+	var totalWeight float64
+	for i, window := range windows {
+		targets[i] = windowWeight(window, weights)
+		totalWeight += targets[i]
+	}
+	if totalWeight == 0 {
+		return allocateTweets(windows, nil, total, floor, ceiling)
+	}
+	for i := range targets {
+		targets[i] = float64(total) * targets[i] / totalWeight
+	}
+
+	remaining := total
+	for i := range counts {
+		counts[i] = min(floor, ceiling, remaining)
+		remaining -= counts[i]
+	}
+
+	// Hand out the rest one at a time to the window furthest below its target
+	for remaining > 0 {
+		best := -1
+		for i := range counts {
+			if counts[i] >= ceiling {
+				continue
+			}
+			if best == -1 || targets[i]-float64(counts[i]) > targets[best]-float64(counts[best]) {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		counts[best]++
+		remaining--
+	}
+	return counts
+}
+
+func generatePostingTimes(window PostingWindow, tweetCount int, today time.Time, weights map[int]float64) []time.Time {
+	if weights != nil && windowWeight(window, weights) > 0 {
+		return weightedPostingTimes(window, tweetCount, today, weights)
+	}
+
 	var times []time.Time
 
 	// Create time range for the window
@@ -132,9 +208,29 @@ func generatePostingTimes(window PostingWindow, tweetCount int, today time.Time)
 	return times
 }
 
-type ScheduledTweet struct {
-	PostTime time.Time
-	Executed bool
+// weightedPostingTimes draws the hour of every tweet from the hour weights and
+// a random minute within it
+func weightedPostingTimes(window PostingWindow, tweetCount int, today time.Time, weights map[int]float64) []time.Time {
+	total := windowWeight(window, weights)
+
+	var times []time.Time
+	for i := 0; i < tweetCount; i++ {
+		hour := window.EndHour
+		pick := rand.Float64() * total
+		for h := window.StartHour; h <= window.EndHour; h++ {
+			if pick < weights[h] {
+				hour = h
+				break
+			}
+			pick -= weights[h]
+		}
+
+		start := time.Date(today.Year(), today.Month(), today.Day(), hour, 0, 0, 0, today.Location())
+		times = append(times, start.Add(time.Duration(rand.Int63n(int64(time.Hour)))))
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
 }
 
 var WeeklySchedule = map[string]DailySchedule{
@@ -197,150 +293,94 @@ var WeeklySchedule = map[string]DailySchedule{
 }
 
 type Scheduler struct {
-	rdb         *redis.Client
-	ctx         context.Context
+	store       scheduler.Store
 	mutex       sync.Mutex
 	location    *time.Location
 	services    *services.Services
 	environment *configs.EnvironmentVariables
 }
 
-func NewScheduler(services *services.Services, environment *configs.EnvironmentVariables) *Scheduler {
-	est, err := time.LoadLocation("America/New_York")
+func NewScheduler(services *services.Services, store scheduler.Store, environment *configs.EnvironmentVariables) (*Scheduler, error) {
+	est, err := time.LoadLocation(environment.Timezone)
 	if err != nil {
-		fmt.Println(err)
-		panic("failed to load EST timezone: %v")
-	}
-
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     environment.RedisCache.Address,
-		Password: environment.RedisCache.Password, // Set if required
-		Username: environment.RedisCache.Username,
-		DB:       0,
-	})
-
-	ctx := context.Background()
-
-	// Test Redis connection
-	if err := rdb.Ping(ctx).Err(); err != nil {
-		fmt.Println(err)
-		panic("failed to connect to Redis: %v")
+		return nil, fmt.Errorf("failed to load schedule timezone: %v", err)
 	}
 
 	return &Scheduler{
-		rdb:         rdb,
-		ctx:         ctx,
+		store:       store,
 		location:    est,
 		services:    services,
 		environment: environment,
-	}
+	}, nil
 }
 
 func (s *Scheduler) Initialize() error {
 	// Set initial daily quota if not exists
-	quotaKey := RedisKeyPrefix + "daily_quota"
-	exists, err := s.rdb.Exists(s.ctx, quotaKey).Result()
+	_, exists, err := s.store.Quota(scheduler.QuotaTweets)
 	if err != nil {
 		return fmt.Errorf("failed to check quota existence: %v", err)
 	}
 
-	if exists == 0 {
+	if !exists {
 		if err := s.resetDailyQuota(); err != nil {
 			return fmt.Errorf("failed to initialize daily quota: %v", err)
 		}
 	}
 
-	return nil
-}
-
-func (s *Scheduler) resetDailyQuota() error {
-	quotaKey := RedisKeyPrefix + "daily_quota"
-	timestampKey := RedisKeyPrefix + "last_reset"
+	// Reply quota is tracked separately so replies never eat into original posts
+	if err = s.store.InitQuota(scheduler.QuotaReplies, s.environment.Mentions.DailyReplyLimit); err != nil {
+		return fmt.Errorf("failed to initialize reply quota: %v", err)
+	}
 
-	// Use Redis transaction to update both values atomically
-	txf := func(tx *redis.Tx) error {
-		// Set new quota
-		if err := tx.Set(s.ctx, quotaKey, DailyTweetLimit, 0).Err(); err != nil {
-			return err
-		}
+	// Values are embedded with the model of the last migration to cut over
+	if err = s.services.EmbeddingService.ActivateModel.Handle(); err != nil {
+		return fmt.Errorf("failed to activate embedding model: %v", err)
+	}
 
-		// Update last reset timestamp using RFC3339 format
-		now := time.Now().In(s.location)
-		if err := tx.Set(s.ctx, timestampKey, now.Format(time.RFC3339), 0).Err(); err != nil {
-			return err
-		}
+	s.beat(time.Now())
+	return nil
+}
 
-		return nil
+// beat tells readiness checks the loop is alive. A missed beat only shows as
+// an older heartbeat, so it never stops the tick
+func (s *Scheduler) beat(now time.Time) {
+	if err := s.store.Beat(now); err != nil {
+		log.Printf("Error recording scheduler heartbeat: %v", err)
 	}
+}
 
-	// Retry transaction if it fails due to WATCH
-	for i := 0; i < 3; i++ {
-		err := s.rdb.Watch(s.ctx, txf, quotaKey, timestampKey)
-		if err == nil {
-			return nil
-		}
-		if errors.Is(err, redis.TxFailedErr) {
-			continue
-		}
+func (s *Scheduler) resetDailyQuota() error {
+	quotas := map[string]int{
+		scheduler.QuotaTweets:  DailyTweetLimit,
+		scheduler.QuotaReplies: s.environment.Mentions.DailyReplyLimit,
+	}
+	if err := s.store.ResetQuotas(quotas, time.Now().In(s.location)); err != nil {
 		return fmt.Errorf("failed to reset quota: %v", err)
 	}
-
-	return fmt.Errorf("failed to reset quota after retries")
+	return nil
 }
 
 func (s *Scheduler) ReserveTweetCapacity(count int) (bool, error) {
-	quotaKey := RedisKeyPrefix + "daily_quota"
-
-	// Use Redis transaction to check and update quota atomically
-	txf := func(tx *redis.Tx) error {
-		// Get current quota
-		remaining, err := tx.Get(s.ctx, quotaKey).Int()
-		if err != nil {
-			return err
-		}
-
-		// Check if enough capacity
-		if remaining < count {
-			return fmt.Errorf("insufficient quota")
-		}
+	return s.reserveCapacity(scheduler.QuotaTweets, count)
+}
 
-		// Decrement quota
-		return tx.Set(s.ctx, quotaKey, remaining-count, 0).Err()
-	}
+func (s *Scheduler) ReserveReplyCapacity(count int) (bool, error) {
+	return s.reserveCapacity(scheduler.QuotaReplies, count)
+}
 
-	// Retry transaction if it fails due to WATCH
-	for i := 0; i < 3; i++ {
-		err := s.rdb.Watch(s.ctx, txf, quotaKey)
-		if err == nil {
-			return true, nil
-		}
-		if errors.Is(err, redis.TxFailedErr) {
-			continue
-		}
-		if err.Error() == "insufficient quota" {
-			return false, nil
-		}
+func (s *Scheduler) reserveCapacity(quota string, count int) (bool, error) {
+	reserved, err := s.store.ReserveQuota(quota, count)
+	if err != nil {
 		return false, fmt.Errorf("failed to reserve capacity: %v", err)
 	}
-
-	return false, fmt.Errorf("failed to reserve capacity after retries")
+	return reserved, nil
 }
 
 func (s *Scheduler) UpdateUsageStats(tweetCount int) error {
 	now := time.Now().In(s.location)
-	dateStr := now.Format("2006-01-02")
-	statsKey := fmt.Sprintf("%susage_stats:%s", RedisKeyPrefix, dateStr)
-
-	// Update daily usage statistics
-	if err := s.rdb.IncrBy(s.ctx, statsKey, int64(tweetCount)).Err(); err != nil {
+	if err := s.store.AddUsage(now.Format("2006-01-02"), tweetCount); err != nil {
 		return fmt.Errorf("failed to update usage stats: %v", err)
 	}
-
-	// Set expiration for stats (keep for 30 days)
-	if err := s.rdb.Expire(s.ctx, statsKey, 30*24*time.Hour).Err(); err != nil {
-		return fmt.Errorf("failed to set stats expiration: %v", err)
-	}
-
 	return nil
 }
 
@@ -358,7 +398,7 @@ func (s *Scheduler) IsWithinPostingWindow() bool {
 }
 
 func (s *Scheduler) AcquireLock(lockKey string) (bool, error) {
-	success, err := s.rdb.SetNX(s.ctx, lockKey, "locked", LockTimeout).Result()
+	success, err := s.store.AcquireLock(lockKey, LockTimeout)
 	if err != nil {
 		return false, fmt.Errorf("failed to acquire lock: %v", err)
 	}
@@ -366,108 +406,247 @@ func (s *Scheduler) AcquireLock(lockKey string) (bool, error) {
 }
 
 func (s *Scheduler) ReleaseLock(lockKey string) error {
-	if err := s.rdb.Del(s.ctx, lockKey).Err(); err != nil {
+	if err := s.store.ReleaseLock(lockKey); err != nil {
 		return fmt.Errorf("failed to release lock: %v", err)
 	}
 	return nil
 }
 
 func (s *Scheduler) checkAndResetQuota() error {
-	timestampKey := RedisKeyPrefix + "last_reset"
-
-	// Get last reset timestamp
-	lastResetStr, err := s.rdb.Get(s.ctx, timestampKey).Result()
-	if err != nil && errors.Is(err, redis.Nil) {
+	lastReset, err := s.store.LastReset()
+	if err != nil {
 		return fmt.Errorf("failed to get last reset timestamp: %v", err)
 	}
 
-	shouldReset := false
-	if errors.Is(err, redis.Nil) {
-		// No last reset timestamp, need to initialize
-		shouldReset = true
-	} else {
-		lastResetUnix, err := time.Parse(time.RFC3339, lastResetStr)
+	// Reset when it never was, or when 24 hours have passed since
+	if lastReset == nil || time.Since(*lastReset) >= 24*time.Hour {
+		if err := s.resetDailyQuota(); err != nil {
+			return fmt.Errorf("failed to reset quota: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) GetSchedule() ([]scheduler.ScheduledTweet, error) {
+	schedules, err := s.store.GetSchedule()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %v", err)
+	}
+	return schedules, nil
+}
+
+func (s *Scheduler) SetSchedule(schedules []scheduler.ScheduledTweet) error {
+	if err := s.store.SetSchedule(schedules); err != nil {
+		return fmt.Errorf("failed to store schedule: %v", err)
+	}
+	return nil
+}
+
+func (s *Scheduler) curationSinceKey(account string) string {
+	return "curation_since:" + account
+}
+
+// RunCuration quotes or retweets new posts from the watched ecosystem accounts. Curated posts
+// draw from the same daily quota as original tweets.
+func (s *Scheduler) RunCuration(ctx context.Context) error {
+	published := 0
+	for _, account := range s.environment.Curation.Accounts {
+		sinceKey := s.curationSinceKey(account)
+		sinceID, err := s.store.GetCursor(sinceKey)
 		if err != nil {
-			return fmt.Errorf("failed to parse last reset timestamp: %v", err)
+			return fmt.Errorf("failed to get curation cursor: %v", err)
 		}
 
-		// Check if 24 hours have passed since last reset
-		if time.Since(lastResetUnix) >= 24*time.Hour {
-			shouldReset = true
+		// Only as many posts as can go out are scored, so none are
+		// evaluated again next run for having missed the cap
+		remaining, _, err := s.store.Quota(scheduler.QuotaTweets)
+		if err != nil {
+			return fmt.Errorf("failed to get remaining tweets: %v", err)
+		}
+		limit := min(s.environment.Curation.MaxPerRun-published, remaining)
+		if limit <= 0 {
+			return nil
 		}
-	}
 
-	if shouldReset {
-		if err := s.resetDailyQuota(); err != nil {
-			return fmt.Errorf("failed to reset quota: %v", err)
+		curations, err := s.services.TweetService.Curate.Candidates(account, sinceID, limit)
+		if err != nil {
+			log.Printf("Error getting curation candidates for %s: %v", account, err)
+			continue
 		}
-	}
 
+		for _, curation := range curations {
+			// Stop with the cursor on the last post handled when shutting down
+			if ctx.Err() != nil {
+				return nil
+			}
+			if curation.Action != command.SKIP {
+				// Leave the cursor on this post so it is reconsidered next run
+				if published >= s.environment.Curation.MaxPerRun {
+					break
+				}
+				reserved, err := s.ReserveTweetCapacity(1)
+				if err != nil {
+					return err
+				}
+				if !reserved {
+					return nil
+				}
+				if err = s.services.TweetService.Curate.Publish(curation); err != nil {
+					log.Printf("Error publishing %s of %s: %v", curation.Action, curation.Post.ID, err)
+					break
+				}
+				if err = s.UpdateUsageStats(1); err != nil {
+					log.Printf("Error updating usage stats: %v", err)
+				}
+				published++
+				log.Printf("Curated %s with a %s (score %.2f)", curation.Post.ID, curation.Action, curation.Score)
+			}
+
+			if err = s.store.SetCursor(sinceKey, curation.Post.ID); err != nil {
+				return fmt.Errorf("failed to store curation cursor: %v", err)
+			}
+		}
+	}
 	return nil
 }
 
-func (s *Scheduler) GetSchedule() ([]ScheduledTweet, error) {
-	key := RedisKeyPrefix + "schedule"
+func (s *Scheduler) replyCooldownKey(authorID string) string {
+	return "reply_cooldown:" + authorID
+}
 
-	scheduleStr, err := s.rdb.Get(s.ctx, key).Result()
-	if err != nil && errors.Is(err, redis.Nil) {
-		return nil, fmt.Errorf("failed to get last reset timestamp: %v", err)
+// RunMentions stores and drafts new mentions, then sends approved replies within the reply
+// quota, skipping authors we replied to recently
+func (s *Scheduler) RunMentions(ctx context.Context, sendReplies bool) error {
+	if _, err := s.services.MentionService.Sync.Handle(); err != nil {
+		return fmt.Errorf("failed to sync mentions: %v", err)
+	}
+	if !sendReplies {
+		return nil
 	}
 
-	if errors.Is(err, redis.Nil) {
-		return nil, nil
+	approved, err := s.services.MentionService.GetMentions.Handle(&mention.GetMentionsParams{Status: mention.StatusApproved})
+	if err != nil {
+		return fmt.Errorf("failed to get approved mentions: %v", err)
+	}
+
+	for i := range approved {
+		if ctx.Err() != nil {
+			return nil
+		}
+		cooldownKey := s.replyCooldownKey(approved[i].AuthorID)
+		cooling, err := s.store.CoolingDown(cooldownKey)
+		if err != nil {
+			return fmt.Errorf("failed to check reply cooldown: %v", err)
+		}
+		if cooling {
+			continue
+		}
+
+		reserved, err := s.ReserveReplyCapacity(1)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			return nil
+		}
+
+		if err = s.services.MentionService.Reply.Handle(&approved[i]); err != nil {
+			log.Printf("Error replying to mention %s: %v", approved[i].TweetID, err)
+			continue
+		}
+		if err = s.store.StartCooldown(cooldownKey, s.environment.Mentions.UserCooldown); err != nil {
+			return fmt.Errorf("failed to set reply cooldown: %v", err)
+		}
+		log.Printf("Replied to mention %s from @%s", approved[i].TweetID, approved[i].AuthorUsername)
 	}
-	var schedules []ScheduledTweet
-	err = json.Unmarshal([]byte(scheduleStr), &schedules)
+	return nil
+}
+
+// RunMetrics snapshots the engagement of recently posted tweets
+func (s *Scheduler) RunMetrics() error {
+	count, err := s.services.AnalyticsService.CollectMetrics.Handle()
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to collect metrics: %v", err)
 	}
-	return schedules, nil
+	log.Printf("Collected metrics for %d tweets", count)
+	return nil
 }
 
-func (s *Scheduler) SetSchedule(schedules []ScheduledTweet) error {
-	key := RedisKeyPrefix + "schedule"
+// RunReembed switches to the model of a migration another process cut
+// over, then re-embeds the next batch of a running embedding migration
+func (s *Scheduler) RunReembed() error {
+	if err := s.services.EmbeddingService.ActivateModel.Handle(); err != nil {
+		return fmt.Errorf("failed to activate embedding model: %v", err)
+	}
 
-	scheduleByte, err := json.Marshal(schedules)
+	count, err := s.services.EmbeddingService.Reembed.Handle()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to re-embed: %v", err)
 	}
+	if count > 0 {
+		log.Printf("Re-embedded %d values", count)
+	}
+	return nil
+}
 
-	// Use Redis transaction to update both values atomically
-	txf := func(tx *redis.Tx) error {
-		// Set new quota
-		if err = tx.Set(s.ctx, key, scheduleByte, 0).Err(); err != nil {
-			return err
-		}
+// planSchedule turns the day's posting times into a schedule, with the
+// content of every tweet planned up front. Without a plan the tweets go out
+// as they always have, with their content left to chance
+func (s *Scheduler) planSchedule(now time.Time, distributions []TweetDistribution) []scheduler.ScheduledTweet {
+	var times []time.Time
+	for _, dist := range distributions {
+		times = append(times, dist.Intervals...)
+	}
 
-		return nil
+	p, err := s.services.TweetService.Planner.Plan(now.Format(time.DateOnly), times)
+	if err != nil {
+		log.Printf("Error planning the content mix: %v", err)
 	}
 
-	// Retry transaction if it fails due to WATCH
-	for i := 0; i < 3; i++ {
-		err := s.rdb.Watch(s.ctx, txf, key)
-		if err == nil {
-			return nil
-		}
-		if errors.Is(err, redis.TxFailedErr) {
-			continue
+	var scheduledTweets []scheduler.ScheduledTweet
+	if p != nil {
+		for i := range p.Slots {
+			scheduledTweets = append(scheduledTweets, scheduler.ScheduledTweet{
+				PostTime: p.Slots[i].PostTime,
+				Executed: false,
+				Slot:     &p.Slots[i],
+			})
 		}
-		return fmt.Errorf("failed to reset quota: %v", err)
+		return scheduledTweets
 	}
-
-	return fmt.Errorf("failed to reset quota after retries")
+	for _, postTime := range times {
+		scheduledTweets = append(scheduledTweets, scheduler.ScheduledTweet{
+			PostTime: postTime,
+			Executed: false,
+		})
+	}
+	return scheduledTweets
 }
 
-func (s *Scheduler) Run() {
+// Run ticks every minute until ctx is done. A publish already under way is
+// finished and checkpointed in the schedule before Run returns, so a thread
+// is never left half posted
+func (s *Scheduler) Run(ctx context.Context) {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
 	var lastDistributionDate time.Time
-
-	for range ticker.C {
+	var lastCurationRun time.Time
+	var lastMentionsRun time.Time
+	var lastMetricsRun time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
 		fmt.Println("Executing Start")
 
 		now := time.Now().In(s.location)
+		s.beat(now)
 
 		// Check and reset quota if needed
 		if err := s.checkAndResetQuota(); err != nil {
@@ -483,15 +662,7 @@ func (s *Scheduler) Run() {
 				continue
 			}
 
-			var scheduledTweets []ScheduledTweet
-			for _, dist := range distributions {
-				for _, postTime := range dist.Intervals {
-					scheduledTweets = append(scheduledTweets, ScheduledTweet{
-						PostTime: postTime,
-						Executed: false,
-					})
-				}
-			}
+			scheduledTweets := s.planSchedule(now, distributions)
 			err = s.SetSchedule(scheduledTweets)
 			if err != nil {
 				log.Printf("Error storing schedules: %v", err)
@@ -500,6 +671,31 @@ func (s *Scheduler) Run() {
 			lastDistributionDate = now
 		}
 
+		if s.IsWithinPostingWindow() && now.Sub(lastCurationRun) >= s.environment.Curation.Interval {
+			if err := s.RunCuration(ctx); err != nil {
+				log.Printf("Error running curation: %v", err)
+			}
+			lastCurationRun = now
+		}
+
+		if now.Sub(lastMentionsRun) >= s.environment.Mentions.Interval {
+			if err := s.RunMentions(ctx, s.IsWithinPostingWindow()); err != nil {
+				log.Printf("Error running mentions: %v", err)
+			}
+			lastMentionsRun = now
+		}
+
+		if now.Sub(lastMetricsRun) >= s.environment.Analytics.Interval {
+			if err := s.RunMetrics(); err != nil {
+				log.Printf("Error collecting metrics: %v", err)
+			}
+			lastMetricsRun = now
+		}
+
+		if err := s.RunReembed(); err != nil {
+			log.Printf("Error running embedding migration: %v", err)
+		}
+
 		scheduledTweets, err := s.GetSchedule()
 		if err != nil {
 			log.Printf("Error getting schedule: %v", err)
@@ -508,6 +704,9 @@ func (s *Scheduler) Run() {
 
 		// Check for tweets that should be posted
 		for i := range scheduledTweets {
+			if ctx.Err() != nil {
+				break
+			}
 			if scheduledTweets[i].Executed {
 				continue
 			}
@@ -515,12 +714,18 @@ func (s *Scheduler) Run() {
 			if math.Abs(now.Sub(scheduledTweets[i].PostTime).Minutes()) <= 5 {
 				fmt.Println("Time to Tweet")
 
-				tweets, reRun, err := s.services.TweetService.Tweet.Tweets()
+				draft, reRun, err := s.services.TweetService.Tweet.Tweets(scheduledTweets[i].Slot)
 				if err != nil || reRun {
 					log.Printf("Error getting tweets: %v", err)
 					continue
 				}
-				reserved, err := s.ReserveTweetCapacity(len(tweets))
+				// Drafting takes a while, so shutdown may have begun since.
+				// Nothing is reserved or sent yet, so the slot is left for
+				// the next run
+				if ctx.Err() != nil {
+					break
+				}
+				reserved, err := s.ReserveTweetCapacity(len(draft.Tweets))
 				if err != nil {
 					log.Printf("Error reserving tweet capacity: %v", err)
 					continue
@@ -529,9 +734,11 @@ func (s *Scheduler) Run() {
 					continue
 				}
 
-				fmt.Println(tweets)
+				for _, tweet := range draft.Tweets {
+					fmt.Println(tweet.Text)
+				}
 				//posting tweet
-				if err = s.services.TweetService.Tweet.SendTweet(tweets); err != nil {
+				if err = s.services.TweetService.Tweet.SendTweet(draft); err != nil {
 					log.Printf("Error posting tweet: %v", err)
 					continue
 				}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateTweets(t *testing.T) {
+	windows := []PostingWindow{
+		{StartHour: 8, EndHour: 12},
+		{StartHour: 12, EndHour: 14},
+		{StartHour: 14, EndHour: 18},
+		{StartHour: 18, EndHour: 22},
+	}
+	evening := map[int]float64{}
+	for hour := 0; hour < 24; hour++ {
+		evening[hour] = 0.01
+	}
+	evening[19], evening[20] = 0.4, 0.3
+
+	tests := []struct {
+		name    string
+		weights map[int]float64
+		total   int
+		floor   int
+		ceiling int
+		want    []int
+	}{
+		{
+			name:    "spreads by duration without weights",
+			weights: nil,
+			total:   17,
+			floor:   1,
+			ceiling: 17,
+			want:    []int{5, 3, 5, 4},
+		},
+		{
+			name:    "favours heavy hours up to the ceiling",
+			weights: evening,
+			total:   17,
+			floor:   1,
+			ceiling: 6,
+			want:    []int{4, 3, 4, 6},
+		},
+		{
+			name:    "keeps the floor for every window",
+			weights: evening,
+			total:   8,
+			floor:   2,
+			ceiling: 10,
+			want:    []int{2, 2, 2, 2},
+		},
+		{
+			name:    "stops at the ceiling when the quota is larger",
+			weights: nil,
+			total:   30,
+			floor:   1,
+			ceiling: 4,
+			want:    []int{4, 4, 4, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, allocateTweets(windows, tt.weights, tt.total, tt.floor, tt.ceiling))
+		})
+	}
+}
+
+func TestGeneratePostingTimes_Weighted(t *testing.T) {
+	window := PostingWindow{StartHour: 18, EndHour: 22}
+	today := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	weights := map[int]float64{18: 0, 19: 1, 20: 0, 21: 0, 22: 0}
+
+	times := generatePostingTimes(window, 3, today, weights)
+	assert.Len(t, times, 3)
+	for _, postTime := range times {
+		assert.Equal(t, 19, postTime.Hour())
+	}
+}
+
+func TestNewScheduler_timezone(t *testing.T) {
+	_, err := NewScheduler(nil, nil, &configs.EnvironmentVariables{Timezone: "Mars/Olympus_Mons"})
+	assert.ErrorContains(t, err, "failed to load schedule timezone")
+}
@@ -0,0 +1,35 @@
+package analytics
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/internals/services/analytics/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/analytics/queries"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	CollectMetrics commands.CollectMetrics
+}
+
+type Queries struct {
+	GetPerformance queries.GetPerformance
+	GetSlotWeights queries.GetSlotWeights
+}
+
+func NewAnalyticsService(repository post.Repository, xdotcom xdotcom.Repository, environmentVariables *configs.EnvironmentVariables) Services {
+	return Services{
+		Commands: Commands{
+			CollectMetrics: commands.NewCollectMetrics(repository, xdotcom, environmentVariables.Analytics.LookbackDays),
+		},
+		Queries: Queries{
+			GetPerformance: queries.NewGetPerformance(repository, environmentVariables.Timezone),
+			GetSlotWeights: queries.NewGetSlotWeights(repository, environmentVariables.Timezone, environmentVariables.PostingTimes),
+		},
+	}
+}
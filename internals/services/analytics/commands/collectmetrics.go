@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+)
+
+type CollectMetrics interface {
+	Handle() (int, error)
+}
+
+type collectMetrics struct {
+	repository   post.Repository
+	xdotcom      xdotcom.Repository
+	lookbackDays int
+}
+
+func NewCollectMetrics(repository post.Repository, xdotcom xdotcom.Repository, lookbackDays int) CollectMetrics {
+	return &collectMetrics{
+		repository, xdotcom, lookbackDays,
+	}
+}
+
+// Handle stores a snapshot of the public metrics of every tweet posted within
+// the lookback window and returns how many were stored
+func (service *collectMetrics) Handle() (int, error) {
+	now := time.Now()
+	posts, err := service.repository.GetPostsSince(now.AddDate(0, 0, -service.lookbackDays))
+	if err != nil {
+		return 0, err
+	}
+
+	postIDs := map[string]int{}
+	var tweetIDs []string
+	for _, p := range posts {
+		for _, tweetID := range p.TweetIDs {
+			postIDs[tweetID] = p.ID
+			tweetIDs = append(tweetIDs, tweetID)
+		}
+	}
+	if len(tweetIDs) == 0 {
+		return 0, nil
+	}
+
+	metrics, err := service.xdotcom.PublicMetrics(tweetIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	// Deleted tweets are missing from the response and are simply skipped
+	var snapshots []post.Snapshot
+	for _, tweetID := range tweetIDs {
+		m, ok := metrics[tweetID]
+		if !ok {
+			continue
+		}
+		snapshots = append(snapshots, post.Snapshot{
+			PostID:        postIDs[tweetID],
+			TweetID:       tweetID,
+			CollectedAt:   now,
+			PublicMetrics: m,
+		})
+	}
+
+	if err = service.repository.AddSnapshots(snapshots); err != nil {
+		return 0, err
+	}
+	return len(snapshots), nil
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	xdotcom2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/xdotcom"
+	"github.com/stretchr/testify/assert"
+)
+
+type postStub struct {
+	posts     []post.Post
+	snapshots []post.Snapshot
+}
+
+func (p *postStub) AddPost(params *post.Post) error {
+	p.posts = append(p.posts, *params)
+	return nil
+}
+
+func (p *postStub) AddCandidates(candidates []post.Candidate) error {
+	return nil
+}
+
+func (p *postStub) GetPostsSince(since time.Time) ([]post.Post, error) {
+	var posts []post.Post
+	for _, item := range p.posts {
+		if !item.PostedAt.Before(since) {
+			posts = append(posts, item)
+		}
+	}
+	return posts, nil
+}
+
+func (p *postStub) AddSnapshots(snapshots []post.Snapshot) error {
+	p.snapshots = append(p.snapshots, snapshots...)
+	return nil
+}
+
+func (p *postStub) GetPerformance(params *post.GetPerformanceParams) ([]post.Performance, error) {
+	return nil, nil
+}
+
+func (p *postStub) GetHourlyEngagement(params *post.GetSlotWeightsParams) ([]post.HourlyEngagement, error) {
+	return nil, nil
+}
+
+func TestCollectMetrics_Handle(t *testing.T) {
+	x := xdotcom2.NewMemoryRepository()
+	x.SetMetrics("1", xdotcom.PublicMetrics{Impressions: 120, Likes: 4})
+	x.SetMetrics("2", xdotcom.PublicMetrics{Impressions: 80, Reposts: 1})
+	x.SetMetrics("3", xdotcom.PublicMetrics{Impressions: 999})
+
+	posts := &postStub{posts: []post.Post{
+		{ID: 1, TweetIDs: []string{"1", "2", "deleted"}, PostedAt: time.Now().Add(-time.Hour)},
+		{ID: 2, TweetIDs: []string{"3"}, PostedAt: time.Now().AddDate(0, 0, -30)},
+	}}
+
+	count, err := NewCollectMetrics(posts, x, 7).Handle()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	if assert.Len(t, posts.snapshots, 2) {
+		assert.Equal(t, 1, posts.snapshots[0].PostID)
+		assert.Equal(t, "1", posts.snapshots[0].TweetID)
+		assert.Equal(t, 120, posts.snapshots[0].Impressions)
+		assert.Equal(t, 1, posts.snapshots[1].Reposts)
+	}
+}
@@ -0,0 +1,26 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+)
+
+type GetPerformance interface {
+	Handle(params *post.GetPerformanceParams) ([]post.Performance, error)
+}
+
+type getPerformance struct {
+	repository post.Repository
+	timezone   string
+}
+
+func NewGetPerformance(repository post.Repository, timezone string) GetPerformance {
+	return &getPerformance{
+		repository, timezone,
+	}
+}
+
+func (service *getPerformance) Handle(params *post.GetPerformanceParams) ([]post.Performance, error) {
+	// Hours and weekdays are reported in the timezone the schedule runs in
+	params.Timezone = service.timezone
+	return service.repository.GetPerformance(params)
+}
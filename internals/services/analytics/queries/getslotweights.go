@@ -0,0 +1,87 @@
+package queries
+
+import (
+	"math"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+const hoursPerDay = 24
+
+type GetSlotWeights interface {
+	Handle(params *post.GetSlotWeightsParams) ([]post.SlotWeight, error)
+}
+
+type getSlotWeights struct {
+	repository post.Repository
+	timezone   string
+	config     *configs.PostingTimes
+}
+
+func NewGetSlotWeights(repository post.Repository, timezone string, config *configs.PostingTimes) GetSlotWeights {
+	return &getSlotWeights{
+		repository, timezone, config,
+	}
+}
+
+// Handle returns a weight for every hour of the weekday, learnt from the
+// engagement of past posts made at that hour
+func (service *getSlotWeights) Handle(params *post.GetSlotWeightsParams) ([]post.SlotWeight, error) {
+	params.Days = service.config.LookbackDays
+	params.Timezone = service.timezone
+
+	hours, err := service.repository.GetHourlyEngagement(params)
+	if err != nil {
+		return nil, err
+	}
+	return SlotWeights(hours, service.config.Exploration, service.config.ExplorationBonus), nil
+}
+
+// SlotWeights scores every hour with an upper confidence bound on its
+// engagement rate, so hours with few posts get a bonus that keeps them being
+// tried, then mixes in a uniform share so no hour ever drops to zero.
+// Without any engagement data every hour weighs the same.
+func SlotWeights(hours []post.HourlyEngagement, exploration, bonus float64) []post.SlotWeight {
+	var byHour [hoursPerDay]post.HourlyEngagement
+	totalPosts, totalImpressions, totalEngagements := 0, 0, 0
+	for _, h := range hours {
+		if h.Hour < 0 || h.Hour >= hoursPerDay {
+			continue
+		}
+		byHour[h.Hour] = h
+		totalPosts += h.Posts
+		totalImpressions += h.Impressions
+		totalEngagements += h.Engagements
+	}
+
+	weights := make([]post.SlotWeight, hoursPerDay)
+	var meanRate float64
+	if totalImpressions > 0 {
+		meanRate = float64(totalEngagements) / float64(totalImpressions)
+	}
+
+	scores := make([]float64, hoursPerDay)
+	var totalScore float64
+	for hour := 0; hour < hoursPerDay; hour++ {
+		h := byHour[hour]
+		weights[hour].Hour = hour
+		weights[hour].Posts = h.Posts
+		if h.Impressions > 0 {
+			weights[hour].EngagementRate = float64(h.Engagements) / float64(h.Impressions)
+		}
+		weights[hour].Exploration = bonus * meanRate * math.Sqrt(math.Log(float64(totalPosts+1))/float64(h.Posts+1))
+
+		scores[hour] = weights[hour].EngagementRate + weights[hour].Exploration
+		totalScore += scores[hour]
+	}
+
+	for hour := range weights {
+		if totalScore == 0 {
+			weights[hour].Weight = 1.0 / hoursPerDay
+			continue
+		}
+		weights[hour].Weight = (1-exploration)*scores[hour]/totalScore + exploration/hoursPerDay
+	}
+	return weights
+}
@@ -0,0 +1,36 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlotWeights(t *testing.T) {
+	t.Run("uniform without data", func(t *testing.T) {
+		weights := SlotWeights(nil, 0.2, 1)
+		assert.Len(t, weights, 24)
+		for _, w := range weights {
+			assert.InDelta(t, 1.0/24, w.Weight, 1e-9)
+		}
+	})
+
+	t.Run("favours the best hour but keeps exploring", func(t *testing.T) {
+		weights := SlotWeights([]post.HourlyEngagement{
+			{Hour: 9, Posts: 10, Impressions: 10000, Engagements: 100},
+			{Hour: 19, Posts: 10, Impressions: 10000, Engagements: 500},
+		}, 0.2, 1)
+
+		var total float64
+		for _, w := range weights {
+			total += w.Weight
+			assert.GreaterOrEqual(t, w.Weight, 0.2/24)
+		}
+		assert.InDelta(t, 1, total, 1e-9)
+		assert.Greater(t, weights[19].Weight, weights[9].Weight)
+		assert.InDelta(t, 0.05, weights[19].EngagementRate, 1e-9)
+		// Untried hours get a bigger exploration bonus than well sampled ones
+		assert.Greater(t, weights[3].Exploration, weights[9].Exploration)
+	})
+}
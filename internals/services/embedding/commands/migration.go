@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+var noMigrationErr = errors.New("no embedding migration is running")
+
+// The hashing provider embeds locally whatever model is asked for, so its
+// values can't be moved to another model, and the configured model is
+// always the one it embeds with
+var hashingMigrationErr = errors.New("the hashing provider has no other model to migrate to")
+
+type StartMigration interface {
+	Handle(params *embedding.StartMigrationParams) (*embedding.Migration, error)
+}
+
+type startMigration struct {
+	repository embedding.Repository
+	config     *configs.Embeddings
+}
+
+func NewStartMigration(repository embedding.Repository, config *configs.Embeddings) StartMigration {
+	return &startMigration{
+		repository,
+		config,
+	}
+}
+
+// Handle starts re-embedding every stored value with a model. The values
+// are re-embedded in the background, a batch at a time
+func (service *startMigration) Handle(params *embedding.StartMigrationParams) (*embedding.Migration, error) {
+	if service.config.Provider == configs.EmbeddingProviderHashing {
+		return nil, appError.BadRequest(hashingMigrationErr)
+	}
+	migration := &embedding.Migration{Model: strings.TrimSpace(params.Model), Dimensions: params.Dimensions}
+	if err := service.repository.StartMigration(migration); err != nil {
+		return nil, err
+	}
+	return service.repository.GetMigration()
+}
+
+type Reembed interface {
+	Handle() (int, error)
+}
+
+type reembed struct {
+	repository embedding.Repository
+	embedder   embedding.Embedder
+	config     *configs.Embeddings
+}
+
+func NewReembed(repository embedding.Repository, embedder embedding.Embedder, config *configs.Embeddings) Reembed {
+	return &reembed{
+		repository,
+		embedder,
+		config,
+	}
+}
+
+// Handle re-embeds the next batch of the running migration and returns how
+// many values it re-embedded. Progress is stored with every batch, so an
+// interrupted migration carries on where it stopped
+func (service *reembed) Handle() (int, error) {
+	// A migration started under another provider waits for it, rather than
+	// storing hashed values under that provider's model
+	if service.config.Provider == configs.EmbeddingProviderHashing {
+		return 0, nil
+	}
+	migration, err := service.repository.GetMigration()
+	if err != nil || migration == nil {
+		return 0, err
+	}
+
+	pending, err := service.repository.PendingEmbeddings(service.config.MigrationBatch)
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+
+	values := make([]string, len(pending))
+	for i, p := range pending {
+		values[i] = p.Value
+	}
+	vectors, err := service.embedder.Embed(migration.Model, migration.Dimensions, values)
+	if err != nil {
+		return 0, err
+	}
+	if len(vectors) != len(pending) {
+		return 0, fmt.Errorf("%s returned %d embeddings for %d values", migration.Model, len(vectors), len(pending))
+	}
+
+	embeddings, passages := map[int][]float32{}, map[int][]float32{}
+	for i, p := range pending {
+		if len(vectors[i]) != migration.Dimensions {
+			return 0, fmt.Errorf("%s returned %d dimensions instead of %d", migration.Model, len(vectors[i]), migration.Dimensions)
+		}
+		if p.Passage {
+			passages[p.ID] = vectors[i]
+		} else {
+			embeddings[p.ID] = vectors[i]
+		}
+	}
+	if err = service.repository.AddShadowEmbeddings(migration.ID, embeddings, passages); err != nil {
+		return 0, err
+	}
+	return len(pending), nil
+}
+
+type CutOver interface {
+	Handle() (*embedding.Migration, error)
+}
+
+type cutOver struct {
+	repository embedding.Repository
+	config     *configs.Embeddings
+}
+
+func NewCutOver(repository embedding.Repository, config *configs.Embeddings) CutOver {
+	return &cutOver{
+		repository,
+		config,
+	}
+}
+
+// Handle swaps the re-embedded values in once every value has been
+// re-embedded, and switches to embedding with the migration's model. Other
+// processes switch when they next activate the model
+func (service *cutOver) Handle() (*embedding.Migration, error) {
+	migration, err := service.repository.GetMigration()
+	if err != nil {
+		return nil, err
+	}
+	if migration == nil {
+		return nil, appError.NotFound(noMigrationErr)
+	}
+	if migration.Done < migration.Total {
+		return nil, appError.Conflict(fmt.Errorf("%d embeddings are still to be re-embedded", migration.Total-migration.Done))
+	}
+
+	if service.config.Provider == configs.EmbeddingProviderHashing {
+		return nil, appError.BadRequest(hashingMigrationErr)
+	}
+	if err = service.repository.CutOver(migration); err != nil {
+		return nil, err
+	}
+	service.config.Use(migration.Model, migration.Dimensions)
+	return migration, nil
+}
+
+type ActivateModel interface {
+	Handle() error
+}
+
+type activateModel struct {
+	repository embedding.Repository
+	config     *configs.Embeddings
+}
+
+func NewActivateModel(repository embedding.Repository, config *configs.Embeddings) ActivateModel {
+	return &activateModel{
+		repository,
+		config,
+	}
+}
+
+// Handle switches to the model of the last migration to cut over. Until a
+// migration has, and always with the hashing provider, the configured
+// model is kept
+func (service *activateModel) Handle() error {
+	if service.config.Provider == configs.EmbeddingProviderHashing {
+		return nil
+	}
+	active, err := service.repository.ActiveModel()
+	if err != nil || active == nil {
+		return err
+	}
+	if model, dimensions := service.config.Active(); model != active.Model || dimensions != active.Dimensions {
+		fmt.Printf("switching embeddings to %s with %d dimensions\n", active.Model, active.Dimensions)
+		service.config.Use(active.Model, active.Dimensions)
+	}
+	return nil
+}
+
+type CancelMigration interface {
+	Handle() error
+}
+
+type cancelMigration struct {
+	repository embedding.Repository
+}
+
+func NewCancelMigration(repository embedding.Repository) CancelMigration {
+	return &cancelMigration{
+		repository,
+	}
+}
+
+// Handle stops the running migration and throws its shadow embeddings away
+func (service *cancelMigration) Handle() error {
+	migration, err := service.repository.GetMigration()
+	if err != nil {
+		return err
+	}
+	if migration == nil {
+		return appError.NotFound(noMigrationErr)
+	}
+	return service.repository.CancelMigration(migration)
+}
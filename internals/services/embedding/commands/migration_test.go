@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// shadowStore keeps stored values and passages in memory with the shadow
+// embeddings of one migration
+type shadowStore struct {
+	migration *embedding.Migration
+	values    []embedding.Pending
+	shadow    map[int][]float32
+	passages  map[int][]float32
+	cutOver   bool
+}
+
+func (s *shadowStore) AddEmbedding(vector []float32, value, contentType string, postID *int) (int, error) {
+	return 0, nil
+}
+
+func (s *shadowStore) Nearest(vector []float32, contentType string) (*embedding.Match, error) {
+	return nil, nil
+}
+
+func (s *shadowStore) Recent(contentType string, limit int) ([]embedding.Match, error) {
+	return nil, nil
+}
+
+func (s *shadowStore) Search(vector []float32, contentType string, limit int) ([]embedding.Result, error) {
+	return nil, nil
+}
+
+func (s *shadowStore) DeleteEmbedding(id int) error {
+	return nil
+}
+
+func (s *shadowStore) StartMigration(migration *embedding.Migration) error {
+	s.migration = migration
+	return nil
+}
+
+func (s *shadowStore) GetMigration() (*embedding.Migration, error) {
+	if s.migration == nil {
+		return nil, nil
+	}
+	s.migration.Total, s.migration.Done = len(s.values), len(s.shadow)+len(s.passages)
+	return s.migration, nil
+}
+
+// shadowed reports whether a value or passage was re-embedded
+func (s *shadowStore) shadowed(value embedding.Pending) bool {
+	if value.Passage {
+		_, ok := s.passages[value.ID]
+		return ok
+	}
+	_, ok := s.shadow[value.ID]
+	return ok
+}
+
+func (s *shadowStore) PendingEmbeddings(limit int) ([]embedding.Pending, error) {
+	var pending []embedding.Pending
+	for _, value := range s.values {
+		if !s.shadowed(value) && len(pending) < limit {
+			pending = append(pending, value)
+		}
+	}
+	return pending, nil
+}
+
+func (s *shadowStore) AddShadowEmbeddings(migrationID int, embeddings, passages map[int][]float32) error {
+	for id, vector := range embeddings {
+		s.shadow[id] = vector
+	}
+	for id, vector := range passages {
+		s.passages[id] = vector
+	}
+	return nil
+}
+
+func (s *shadowStore) CutOver(migration *embedding.Migration) error {
+	s.cutOver = true
+	return nil
+}
+
+func (s *shadowStore) ActiveModel() (*embedding.Migration, error) {
+	if !s.cutOver {
+		return nil, nil
+	}
+	return s.migration, nil
+}
+
+func (s *shadowStore) CancelMigration(migration *embedding.Migration) error {
+	s.migration = nil
+	return nil
+}
+
+// sizedEmbedder embeds every value as a vector of the value's length
+type sizedEmbedder struct {
+	calls int
+}
+
+func (e *sizedEmbedder) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	e.calls++
+	vectors := make([][]float32, len(values))
+	for i, value := range values {
+		vectors[i] = make([]float32, len(value))
+	}
+	return vectors, nil
+}
+
+func TestReembed(t *testing.T) {
+	store := &shadowStore{
+		values:   []embedding.Pending{{ID: 1, Value: "abc"}, {ID: 2, Value: "def"}, {ID: 3, Value: "ghi"}, {ID: 1, Value: "jkl", Passage: true}},
+		shadow:   map[int][]float32{},
+		passages: map[int][]float32{},
+	}
+	embedder := &sizedEmbedder{}
+	config := &configs.Embeddings{Model: "text-embedding-3-large", MigrationBatch: 2}
+	reembed := NewReembed(store, embedder, config)
+	cutOver := NewCutOver(store, config)
+
+	// Nothing happens without a migration
+	count, err := reembed.Handle()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Zero(t, embedder.calls)
+
+	migration, err := NewStartMigration(store, config).Handle(&embedding.StartMigrationParams{Model: " text-embedding-3-small ", Dimensions: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "text-embedding-3-small", migration.Model)
+
+	count, err = reembed.Handle()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Cutting over waits for the last batch, passages included
+	_, err = cutOver.Handle()
+	assert.EqualError(t, err, "2 embeddings are still to be re-embedded")
+	assert.False(t, store.cutOver)
+
+	count, err = reembed.Handle()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, store.shadow, 3)
+	assert.Len(t, store.passages[1], 3)
+	count, err = reembed.Handle()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Values are embedded with the migration's model from then on
+	_, err = cutOver.Handle()
+	assert.NoError(t, err)
+	assert.True(t, store.cutOver)
+	model, dimensions := config.Active()
+	assert.Equal(t, "text-embedding-3-small", model)
+	assert.Equal(t, 3, dimensions)
+}
+
+func TestActivateModel(t *testing.T) {
+	store := &shadowStore{migration: &embedding.Migration{ID: 1, Model: "text-embedding-3-small", Dimensions: 512}}
+	config := &configs.Embeddings{Model: "text-embedding-3-large"}
+	activate := NewActivateModel(store, config)
+
+	// The configured model is kept until a migration cuts over
+	assert.NoError(t, activate.Handle())
+	model, _ := config.Active()
+	assert.Equal(t, "text-embedding-3-large", model)
+
+	store.cutOver = true
+	assert.NoError(t, activate.Handle())
+	model, dimensions := config.Active()
+	assert.Equal(t, "text-embedding-3-small", model)
+	assert.Equal(t, 512, dimensions)
+}
+
+func TestReembed_wrongDimensions(t *testing.T) {
+	store := &shadowStore{
+		migration: &embedding.Migration{ID: 1, Model: "text-embedding-3-small", Dimensions: 3},
+		values:    []embedding.Pending{{ID: 1, Value: "abcd"}},
+		shadow:    map[int][]float32{},
+	}
+
+	_, err := NewReembed(store, &sizedEmbedder{}, &configs.Embeddings{MigrationBatch: 10}).Handle()
+	assert.EqualError(t, err, "text-embedding-3-small returned 4 dimensions instead of 3")
+	assert.Empty(t, store.shadow)
+}
+
+func TestMigration_hashing(t *testing.T) {
+	store := &shadowStore{migration: &embedding.Migration{ID: 1, Model: "text-embedding-3-small", Dimensions: 3}, cutOver: true}
+	config := &configs.Embeddings{Provider: configs.EmbeddingProviderHashing, Model: "feature-hashing"}
+
+	_, err := NewStartMigration(store, config).Handle(&embedding.StartMigrationParams{Model: "text-embedding-3-small", Dimensions: 3})
+	assert.Error(t, err)
+	_, err = NewCutOver(store, config).Handle()
+	assert.Error(t, err)
+
+	// A migration cut over under another provider is not switched to
+	assert.NoError(t, NewActivateModel(store, config).Handle())
+	model, _ := config.Active()
+	assert.Equal(t, "feature-hashing", model)
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+)
+
+type Unblock interface {
+	Handle(id int) error
+}
+
+type unblock struct {
+	repository embedding.Repository
+}
+
+func NewUnblock(repository embedding.Repository) Unblock {
+	return &unblock{
+		repository,
+	}
+}
+
+// Handle forgets a stored topic or tweet, so one like it can be generated
+// and published again. Whatever was published stays in the post history
+func (service *unblock) Handle(id int) error {
+	return service.repository.DeleteEmbedding(id)
+}
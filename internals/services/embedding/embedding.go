@@ -0,0 +1,46 @@
+package embedding
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/services/embedding/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/embedding/queries"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	StartMigration  commands.StartMigration
+	Reembed         commands.Reembed
+	CutOver         commands.CutOver
+	CancelMigration commands.CancelMigration
+	ActivateModel   commands.ActivateModel
+	Unblock         commands.Unblock
+}
+
+type Queries struct {
+	GetMigration  queries.GetMigration
+	Search        queries.Search
+	GetCacheStats queries.GetCacheStats
+}
+
+func NewEmbeddingService(repository embedding.Repository, embedder embedding.Embedder, counter embedding.CacheCounter, environmentVariables *configs.EnvironmentVariables) Services {
+	return Services{
+		Commands: Commands{
+			StartMigration:  commands.NewStartMigration(repository, environmentVariables.Embeddings),
+			Reembed:         commands.NewReembed(repository, embedder, environmentVariables.Embeddings),
+			CutOver:         commands.NewCutOver(repository, environmentVariables.Embeddings),
+			CancelMigration: commands.NewCancelMigration(repository),
+			ActivateModel:   commands.NewActivateModel(repository, environmentVariables.Embeddings),
+			Unblock:         commands.NewUnblock(repository),
+		},
+		Queries: Queries{
+			GetMigration:  queries.NewGetMigration(repository),
+			Search:        queries.NewSearch(repository, embedder, environmentVariables.Embeddings),
+			GetCacheStats: queries.NewGetCacheStats(counter),
+		},
+	}
+}
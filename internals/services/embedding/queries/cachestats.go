@@ -0,0 +1,25 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+)
+
+type GetCacheStats interface {
+	Handle() embedding.CacheStats
+}
+
+type getCacheStats struct {
+	counter embedding.CacheCounter
+}
+
+func NewGetCacheStats(counter embedding.CacheCounter) GetCacheStats {
+	return &getCacheStats{
+		counter,
+	}
+}
+
+// Handle returns how many embeddings this instance served from the cache
+// and how many it requested from the provider
+func (service *getCacheStats) Handle() embedding.CacheStats {
+	return service.counter.CacheStats()
+}
@@ -0,0 +1,33 @@
+package queries
+
+import (
+	"errors"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type GetMigration interface {
+	Handle() (*embedding.Migration, error)
+}
+
+type getMigration struct {
+	repository embedding.Repository
+}
+
+func NewGetMigration(repository embedding.Repository) GetMigration {
+	return &getMigration{
+		repository,
+	}
+}
+
+func (service *getMigration) Handle() (*embedding.Migration, error) {
+	migration, err := service.repository.GetMigration()
+	if err != nil {
+		return nil, err
+	}
+	if migration == nil {
+		return nil, appError.NotFound(errors.New("no embedding migration is running"))
+	}
+	return migration, nil
+}
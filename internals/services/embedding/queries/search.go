@@ -0,0 +1,45 @@
+package queries
+
+import (
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+const defaultSearchLimit = 10
+
+type Search interface {
+	Handle(params *embedding.SearchParams) ([]embedding.Result, error)
+}
+
+type search struct {
+	repository embedding.Repository
+	embedder   embedding.Embedder
+	config     *configs.Embeddings
+}
+
+func NewSearch(repository embedding.Repository, embedder embedding.Embedder, config *configs.Embeddings) Search {
+	return &search{
+		repository,
+		embedder,
+		config,
+	}
+}
+
+// Handle finds the stored topics and tweets closest in meaning to free
+// text. The text is embedded with the configured model, so it is only
+// compared with values embedded the same way
+func (service *search) Handle(params *embedding.SearchParams) ([]embedding.Result, error) {
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	model, dimensions := service.config.Active()
+	embeddings, err := service.embedder.Embed(model, dimensions, []string{strings.TrimSpace(params.Query)})
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.Search(embeddings[0], params.ContentType, limit)
+}
@@ -0,0 +1,62 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// searchStore records the search it was asked for
+type searchStore struct {
+	embedding.Repository
+	vector      []float32
+	contentType string
+	limit       int
+}
+
+func (s *searchStore) Search(vector []float32, contentType string, limit int) ([]embedding.Result, error) {
+	s.vector, s.contentType, s.limit = vector, contentType, limit
+	return []embedding.Result{}, nil
+}
+
+// modelEmbedder embeds every value as a vector of the value's length and
+// records the model it was asked for
+type modelEmbedder struct {
+	model string
+}
+
+func (e *modelEmbedder) Embed(model string, dimensions int, values []string) ([][]float32, error) {
+	e.model = model
+	vectors := make([][]float32, len(values))
+	for i, value := range values {
+		vectors[i] = make([]float32, len(value))
+	}
+	return vectors, nil
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		params embedding.SearchParams
+		limit  int
+	}{
+		{name: "default limit", params: embedding.SearchParams{Query: " XCM fees ", ContentType: embedding.ContentTopic}, limit: defaultSearchLimit},
+		{name: "requested limit", params: embedding.SearchParams{Query: "XCM fees", Limit: 3}, limit: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &searchStore{}
+			embedder := &modelEmbedder{}
+			service := NewSearch(store, embedder, &configs.Embeddings{Model: "text-embedding-3-small"})
+
+			_, err := service.Handle(&tt.params)
+			assert.NoError(t, err)
+			assert.Equal(t, "text-embedding-3-small", embedder.model)
+			assert.Len(t, store.vector, len("XCM fees"))
+			assert.Equal(t, tt.params.ContentType, store.contentType)
+			assert.Equal(t, tt.limit, store.limit)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type CreateExperiment interface {
+	Handle(params *experiment.CreateExperimentParams) (*experiment.Experiment, error)
+}
+
+type createExperiment struct {
+	repository experiment.Repository
+}
+
+func NewCreateExperiment(repository experiment.Repository) CreateExperiment {
+	return &createExperiment{
+		repository,
+	}
+}
+
+// Handle starts an experiment on a template. A running experiment on the same
+// template has to be stopped first, while a promoted one is retired
+func (service *createExperiment) Handle(params *experiment.CreateExperimentParams) (*experiment.Experiment, error) {
+	e := &experiment.Experiment{
+		Name:     strings.TrimSpace(params.Name),
+		Template: params.Template,
+		Status:   experiment.StatusRunning,
+	}
+
+	seen := map[string]bool{}
+	for _, v := range params.Variants {
+		name := strings.TrimSpace(v.Name)
+		if seen[name] {
+			return nil, appError.BadRequest(fmt.Errorf("variant %q is duplicated", name))
+		}
+		seen[name] = true
+
+		if err := ValidateBody(v.Body); err != nil {
+			return nil, appError.BadRequest(fmt.Errorf("variant %q: %v", name, err))
+		}
+		e.Variants = append(e.Variants, experiment.Variant{Name: name, Body: v.Body, Weight: v.Weight})
+	}
+
+	active, err := service.repository.GetActiveExperiment(params.Template)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		if active.Status == experiment.StatusRunning {
+			return nil, appError.Conflict(fmt.Errorf("experiment %q is already running on %s", active.Name, active.Template))
+		}
+		now := time.Now()
+		active.Status = experiment.StatusStopped
+		active.StoppedAt = &now
+		if err = service.repository.UpdateExperiment(active); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = service.repository.AddExperiment(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ValidateBody parses a variant body and renders it with sample data, so a
+// broken template is caught before it reaches the generator
+func ValidateBody(body string) error {
+	tmpl, err := template.New("variant").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return err
+	}
+
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, prompt.Data{
+		Topic:   "sample topic",
+		Context: "sample context",
+		Limits:  prompt.Limits{MaxTweetLength: 280, TweetCharacters: 250, MaxThreadTweets: 3, MinPollOptions: 2, MaxPollOptions: 4, MaxPollOptionLength: 25},
+	})
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(rendered.String()) == "" {
+		return errors.New("template renders an empty prompt")
+	}
+	return nil
+}
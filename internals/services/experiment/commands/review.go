@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type StopExperiment interface {
+	Handle(id int) error
+}
+
+type stopExperiment struct {
+	repository experiment.Repository
+}
+
+func NewStopExperiment(repository experiment.Repository) StopExperiment {
+	return &stopExperiment{
+		repository,
+	}
+}
+
+// Handle ends an experiment, sending the template back to its default prompt
+func (service *stopExperiment) Handle(id int) error {
+	e, err := service.repository.GetExperiment(id)
+	if err != nil {
+		return err
+	}
+	if e.Status == experiment.StatusStopped {
+		return appError.Conflict(errors.New("experiment is already stopped"))
+	}
+
+	now := time.Now()
+	e.Status = experiment.StatusStopped
+	e.StoppedAt = &now
+	return service.repository.UpdateExperiment(e)
+}
+
+type PromoteExperiment interface {
+	Handle(params *experiment.PromoteExperimentParams) error
+}
+
+type promoteExperiment struct {
+	repository experiment.Repository
+}
+
+func NewPromoteExperiment(repository experiment.Repository) PromoteExperiment {
+	return &promoteExperiment{
+		repository,
+	}
+}
+
+// Handle ends a running experiment and sends all of the template's traffic to the winning variant
+func (service *promoteExperiment) Handle(params *experiment.PromoteExperimentParams) error {
+	e, err := service.repository.GetExperiment(params.ID)
+	if err != nil {
+		return err
+	}
+	if e.Status != experiment.StatusRunning {
+		return appError.Conflict(errors.New("only running experiments can be promoted"))
+	}
+
+	found := false
+	for _, v := range e.Variants {
+		if v.ID == params.VariantID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return appError.BadRequest(errors.New("variant does not belong to the experiment"))
+	}
+
+	now := time.Now()
+	e.Status = experiment.StatusPromoted
+	e.WinnerVariantID = &params.VariantID
+	e.StoppedAt = &now
+	return service.repository.UpdateExperiment(e)
+}
@@ -0,0 +1,37 @@
+package experiment
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/services/experiment/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/experiment/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	CreateExperiment  commands.CreateExperiment
+	StopExperiment    commands.StopExperiment
+	PromoteExperiment commands.PromoteExperiment
+}
+
+type Queries struct {
+	GetExperiments queries.GetExperiments
+	GetResults     queries.GetResults
+}
+
+func NewExperimentService(repository experiment.Repository) Services {
+	return Services{
+		Commands: Commands{
+			CreateExperiment:  commands.NewCreateExperiment(repository),
+			StopExperiment:    commands.NewStopExperiment(repository),
+			PromoteExperiment: commands.NewPromoteExperiment(repository),
+		},
+		Queries: Queries{
+			GetExperiments: queries.NewGetExperiments(repository),
+			GetResults:     queries.NewGetResults(repository),
+		},
+	}
+}
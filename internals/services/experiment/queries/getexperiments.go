@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+)
+
+type GetExperiments interface {
+	Handle() ([]experiment.Experiment, error)
+}
+
+type getExperiments struct {
+	repository experiment.Repository
+}
+
+func NewGetExperiments(repository experiment.Repository) GetExperiments {
+	return &getExperiments{
+		repository,
+	}
+}
+
+func (service *getExperiments) Handle() ([]experiment.Experiment, error) {
+	return service.repository.GetExperiments()
+}
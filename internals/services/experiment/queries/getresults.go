@@ -0,0 +1,91 @@
+package queries
+
+import (
+	"math"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+)
+
+// significanceLevel is the p-value below which a difference is reported as significant
+const significanceLevel = 0.05
+
+type GetResults interface {
+	Handle(id int) (*experiment.Experiment, []experiment.Result, error)
+}
+
+type getResults struct {
+	repository experiment.Repository
+}
+
+func NewGetResults(repository experiment.Repository) GetResults {
+	return &getResults{
+		repository,
+	}
+}
+
+func (service *getResults) Handle(id int) (*experiment.Experiment, []experiment.Result, error) {
+	e, err := service.repository.GetExperiment(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	metrics, err := service.repository.GetVariantMetrics(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, CompareVariants(e.Variants, metrics), nil
+}
+
+// CompareVariants tests every variant's engagement rate against the first
+// variant's with a two-proportion z-test, treating each impression as a trial.
+// Counts that can't be a proportion, with more engagements than impressions,
+// are reported as insufficient data rather than tested
+func CompareVariants(variants []experiment.Variant, metrics []experiment.VariantMetrics) []experiment.Result {
+	byVariant := map[int]experiment.VariantMetrics{}
+	for _, m := range metrics {
+		byVariant[m.VariantID] = m
+	}
+
+	results := make([]experiment.Result, len(variants))
+	for i, v := range variants {
+		m := byVariant[v.ID]
+		results[i] = experiment.Result{
+			VariantID:   v.ID,
+			Name:        v.Name,
+			Control:     i == 0,
+			Posts:       m.Posts,
+			Impressions: m.Impressions,
+			Engagements: m.Engagements,
+			PValue:      1,
+		}
+		if m.Impressions <= 0 || m.Engagements < 0 || m.Engagements > m.Impressions {
+			results[i].InsufficientData = true
+			continue
+		}
+		results[i].EngagementRate = float64(m.Engagements) / float64(m.Impressions)
+	}
+	if len(results) == 0 {
+		return results
+	}
+
+	control := results[0]
+	for i := 1; i < len(results); i++ {
+		r := &results[i]
+		if control.InsufficientData || r.InsufficientData {
+			r.InsufficientData = true
+			continue
+		}
+		if control.EngagementRate > 0 {
+			r.Lift = (r.EngagementRate - control.EngagementRate) / control.EngagementRate
+		}
+
+		pooled := float64(control.Engagements+r.Engagements) / float64(control.Impressions+r.Impressions)
+		standardError := math.Sqrt(pooled * (1 - pooled) * (1/float64(control.Impressions) + 1/float64(r.Impressions)))
+		if standardError == 0 {
+			continue
+		}
+		r.ZScore = (r.EngagementRate - control.EngagementRate) / standardError
+		r.PValue = math.Erfc(math.Abs(r.ZScore) / math.Sqrt2)
+		r.Significant = r.PValue < significanceLevel
+	}
+	return results
+}
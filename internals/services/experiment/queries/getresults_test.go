@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVariants(t *testing.T) {
+	variants := []experiment.Variant{
+		{ID: 1, Name: "control"},
+		{ID: 2, Name: "punchy"},
+		{ID: 3, Name: "question"},
+		{ID: 4, Name: "unused"},
+	}
+	metrics := []experiment.VariantMetrics{
+		{VariantID: 1, Posts: 20, Impressions: 20000, Engagements: 400},
+		{VariantID: 2, Posts: 20, Impressions: 20000, Engagements: 560},
+		{VariantID: 3, Posts: 20, Impressions: 20000, Engagements: 410},
+	}
+
+	results := CompareVariants(variants, metrics)
+	assert.Len(t, results, 4)
+
+	assert.True(t, results[0].Control)
+	assert.InDelta(t, 0.02, results[0].EngagementRate, 1e-9)
+
+	assert.InDelta(t, 0.4, results[1].Lift, 1e-9)
+	assert.Greater(t, results[1].ZScore, 1.96)
+	assert.True(t, results[1].Significant)
+
+	assert.False(t, results[2].Significant)
+	assert.Greater(t, results[2].PValue, 0.05)
+
+	assert.Equal(t, 0, results[3].Posts)
+	assert.Equal(t, 1.0, results[3].PValue)
+	assert.False(t, results[3].Significant)
+	assert.True(t, results[3].InsufficientData)
+}
+
+func TestCompareVariants_InvalidCounts(t *testing.T) {
+	variants := []experiment.Variant{{ID: 1, Name: "control"}, {ID: 2, Name: "punchy"}}
+
+	// More engagements than impressions would take the pooled rate past 1
+	results := CompareVariants(variants, []experiment.VariantMetrics{
+		{VariantID: 1, Posts: 5, Impressions: 100, Engagements: 40},
+		{VariantID: 2, Posts: 5, Impressions: 100, Engagements: 300},
+	})
+	assert.False(t, results[0].InsufficientData)
+	assert.True(t, results[1].InsufficientData)
+	assert.Equal(t, 1.0, results[1].PValue)
+	assert.Zero(t, results[1].ZScore)
+
+	// Without a usable control nothing can be compared
+	results = CompareVariants(variants, []experiment.VariantMetrics{
+		{VariantID: 1, Posts: 5, Impressions: 0, Engagements: 3},
+		{VariantID: 2, Posts: 5, Impressions: 100, Engagements: 30},
+	})
+	assert.True(t, results[1].InsufficientData)
+	assert.Zero(t, results[1].Lift)
+
+	_, err := json.Marshal(results)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,23 @@
+package health
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/internals/services/health/queries"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type Services struct {
+	Queries
+}
+
+type Queries struct {
+	Ready queries.Ready
+}
+
+func NewHealthService(checkers []health.Checker, environmentVariables *configs.EnvironmentVariables) Services {
+	return Services{
+		Queries: Queries{
+			Ready: queries.NewReady(checkers, environmentVariables.Health),
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package queries
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type Ready interface {
+	Handle() health.Report
+}
+
+type ready struct {
+	checkers []health.Checker
+	config   *configs.Health
+	// mutex also makes concurrent probes wait for one round of checks
+	// rather than start their own
+	mutex   sync.Mutex
+	results map[string]health.Component
+	now     func() time.Time
+}
+
+func NewReady(checkers []health.Checker, config *configs.Health) Ready {
+	return &ready{
+		checkers: checkers,
+		config:   config,
+		results:  map[string]health.Component{},
+		now:      time.Now,
+	}
+}
+
+// Handle checks every dependency, reusing results younger than the cache
+// TTL. Stale checks run concurrently, each within the check timeout
+func (service *ready) Handle() health.Report {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	components := make([]health.Component, len(service.checkers))
+	for i, checker := range service.checkers {
+		if result, ok := service.results[checker.Name()]; ok && service.now().Sub(result.CheckedAt) < service.config.CacheTTL {
+			components[i] = result
+			continue
+		}
+		wg.Add(1)
+		go func(i int, checker health.Checker) {
+			defer wg.Done()
+			components[i] = service.check(checker)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	report := health.Report{Status: health.StatusUp, Components: components}
+	for _, component := range components {
+		service.results[component.Name] = component
+		if component.Status != health.StatusUp {
+			report.Status = health.StatusDown
+		}
+	}
+	return report
+}
+
+func (service *ready) check(checker health.Checker) health.Component {
+	ctx, cancel := context.WithTimeout(context.Background(), service.config.CheckTimeout)
+	defer cancel()
+
+	// Don't wait on a check that ignores its deadline
+	started := service.now()
+	done := make(chan error, 1)
+	go func() {
+		done <- checker.Check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	component := health.Component{
+		Name:      checker.Name(),
+		Status:    health.StatusUp,
+		LatencyMS: service.now().Sub(started).Milliseconds(),
+		CheckedAt: started,
+	}
+	if err != nil {
+		component.Status = health.StatusDown
+		component.Error = err.Error()
+	}
+	return component
+}
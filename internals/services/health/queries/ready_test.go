@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/health"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubChecker struct {
+	name  string
+	err   error
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (checker *stubChecker) Name() string {
+	return checker.name
+}
+
+func (checker *stubChecker) Check(ctx context.Context) error {
+	checker.calls.Add(1)
+	time.Sleep(checker.delay)
+	return checker.err
+}
+
+func TestReady_Handle(t *testing.T) {
+	postgres := &stubChecker{name: "postgres"}
+	x := &stubChecker{name: "x", err: errors.New("unauthorized")}
+	openai := &stubChecker{name: "openai", delay: 200 * time.Millisecond}
+	config := &configs.Health{CheckTimeout: 50 * time.Millisecond, CacheTTL: time.Minute}
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := NewReady([]health.Checker{postgres, x, openai}, config).(*ready)
+	service.now = func() time.Time { return now }
+
+	report := service.Handle()
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, report.Components[0].Status)
+	assert.Equal(t, "unauthorized", report.Components[1].Error)
+	assert.Equal(t, health.StatusDown, report.Components[2].Status, "a check past its timeout is down")
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components[2].Error)
+
+	service.Handle()
+	assert.EqualValues(t, 1, x.calls.Load(), "results are reused within the cache TTL")
+
+	now = now.Add(time.Minute)
+	service.Handle()
+	assert.EqualValues(t, 2, x.calls.Load())
+	assert.EqualValues(t, 2, postgres.calls.Load())
+}
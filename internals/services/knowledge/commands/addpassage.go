@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type AddPassage interface {
+	Handle(params *knowledge.AddPassageParams) error
+}
+
+type addPassage struct {
+	llm        llm.Repository
+	repository knowledge.Repository
+}
+
+func NewAddPassage(llm llm.Repository, repository knowledge.Repository) AddPassage {
+	return &addPassage{
+		llm, repository,
+	}
+}
+
+func (service *addPassage) Handle(params *knowledge.AddPassageParams) error {
+	params.Content = strings.TrimSpace(params.Content)
+	if params.Content == "" {
+		return appError.BadRequest(errors.New("passage content is empty"))
+	}
+
+	embedding, err := service.llm.Embed(params.Content)
+	if err != nil {
+		return err
+	}
+	return service.repository.AddPassage(params, embedding)
+}
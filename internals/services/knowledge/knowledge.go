@@ -0,0 +1,32 @@
+package knowledge
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/services/knowledge/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/knowledge/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	AddPassage commands.AddPassage
+}
+
+type Queries struct {
+	Search queries.Search
+}
+
+func NewKnowledgeService(llm llm.Repository, repository knowledge.Repository) Services {
+	return Services{
+		Commands: Commands{
+			AddPassage: commands.NewAddPassage(llm, repository),
+		},
+		Queries: Queries{
+			Search: queries.NewSearch(llm, repository),
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+)
+
+const defaultSearchLimit = 5
+
+type Search interface {
+	Handle(params *knowledge.SearchParams) ([]knowledge.ScoredPassage, error)
+}
+
+type search struct {
+	llm        llm.Repository
+	repository knowledge.Repository
+}
+
+func NewSearch(llm llm.Repository, repository knowledge.Repository) Search {
+	return &search{
+		llm, repository,
+	}
+}
+
+func (service *search) Handle(params *knowledge.SearchParams) ([]knowledge.ScoredPassage, error) {
+	if params.Limit == 0 {
+		params.Limit = defaultSearchLimit
+	}
+
+	embedding, err := service.llm.Embed(params.Query)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.NearestPassages(embedding, params.Limit)
+}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+)
+
+func ClassifyMentionPrompt(post xdotcom.Post) string {
+	return fmt.Sprintf("You moderate replies to a Polkadot community account on Twitter. Classify the post below.\n\n[POST BY @%s: %s]\n\nCategories:\n- question: asks something about Polkadot, its ecosystem or the account's content\n- praise: thanks, agrees with or compliments the account\n- spam: promotion, scams, giveaways, links or content unrelated to the conversation\n- hostile: insults, harassment or bad-faith attacks\n\nReturn a JSON object in this format:\n{\"classification\": \"question\", \"confidence\": 0.92}\n\nconfidence is a number between 0 and 1 for how sure you are. Return only the JSON object, with no additional text, formatting, or explanation.", post.AuthorUsername, post.Text)
+}
+
+func DraftReplyPrompt(post xdotcom.Post, classification string, passages []knowledge.ScoredPassage, voice *persona.Persona) string {
+	var background strings.Builder
+	for _, passage := range passages {
+		background.WriteString("- " + passage.Content + "\n")
+	}
+	return fmt.Sprintf("# Reply Generation Prompt\n[POST BY @%s: %s]\n[CLASSIFICATION: %s]\n[BACKGROUND:\n%s]\n\nYou run a Polkadot community account and are replying to the post above.\n\n## Requirements\n- If it is a question, answer it directly using only facts from the background. If the background does not cover it, say so briefly and point them to the Polkadot Wiki\n- If it is praise, thank them warmly in one sentence\n- Under 260 characters\n- Friendly and knowledgeable, never a price prediction or financial advice\n- Follow the persona's hashtag and emoji policy\n\n%s\n\nReturn only the reply text, with no quotes, formatting, or explanation.", post.AuthorUsername, post.Text, classification, background.String(), voice.Instructions())
+}
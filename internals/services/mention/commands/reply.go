@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type Reply interface {
+	Handle(m *mention.Mention) error
+}
+
+type reply struct {
+	repository mention.Repository
+	xdotcom    xdotcom.Repository
+	policy     *policy.Engine
+}
+
+func NewReply(repository mention.Repository, xdotcom xdotcom.Repository, engine *policy.Engine) Reply {
+	return &reply{
+		repository, xdotcom, engine,
+	}
+}
+
+// Handle posts an approved draft as a reply to the mention. The draft is
+// reviewed against the content policy first, since a person may have
+// rewritten it; one the policy blocks goes back to pending
+func (service *reply) Handle(m *mention.Mention) error {
+	if m.Status != mention.StatusApproved {
+		return appError.Conflict(errors.New("only approved mentions can be replied to"))
+	}
+
+	reviewed, report, err := service.policy.ReviewText(m.Draft)
+	if err != nil {
+		return err
+	}
+	if report.Verdict == policy.Block {
+		m.Status = mention.StatusPending
+		if err = service.repository.UpdateMention(m); err != nil {
+			return err
+		}
+		return appError.Conflict(errors.New("reply blocked by content policy, it is pending review again"))
+	}
+	m.Draft = reviewed
+
+	id, err := service.xdotcom.Tweet(xdotcom.Tweet{
+		Text:            m.Draft,
+		PreviousTweetID: m.TweetID,
+	})
+	if err != nil {
+		return err
+	}
+
+	m.Status = mention.StatusReplied
+	m.ReplyTweetID = id
+	return service.repository.UpdateMention(m)
+}
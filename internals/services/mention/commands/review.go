@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type Approve interface {
+	Handle(params *mention.ApproveMentionParams) error
+}
+
+type approve struct {
+	repository mention.Repository
+}
+
+func NewApprove(repository mention.Repository) Approve {
+	return &approve{
+		repository,
+	}
+}
+
+// Handle queues a pending draft for sending, optionally replacing its text
+func (service *approve) Handle(params *mention.ApproveMentionParams) error {
+	m, err := service.repository.GetMention(params.ID)
+	if err != nil {
+		return err
+	}
+	if m.Status != mention.StatusPending {
+		return appError.Conflict(errors.New("only pending mentions can be approved"))
+	}
+
+	if draft := strings.TrimSpace(params.Draft); draft != "" {
+		m.Draft = draft
+	}
+	if m.Draft == "" {
+		return appError.BadRequest(errors.New("mention has no reply draft"))
+	}
+	if len([]rune(m.Draft)) > maxReplyLength {
+		return appError.BadRequest(errors.New("reply draft is longer than 280 characters"))
+	}
+
+	m.Status = mention.StatusApproved
+	return service.repository.UpdateMention(m)
+}
+
+type Reject interface {
+	Handle(id int) error
+}
+
+type reject struct {
+	repository mention.Repository
+}
+
+func NewReject(repository mention.Repository) Reject {
+	return &reject{
+		repository,
+	}
+}
+
+func (service *reject) Handle(id int) error {
+	m, err := service.repository.GetMention(id)
+	if err != nil {
+		return err
+	}
+	if m.Status == mention.StatusReplied {
+		return appError.Conflict(errors.New("mention has already been replied to"))
+	}
+
+	m.Status = mention.StatusRejected
+	return service.repository.UpdateMention(m)
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+const (
+	replyPassageLimit = 3
+	maxReplyLength    = 280
+)
+
+type Sync interface {
+	Handle() (int, error)
+}
+
+type syncMentions struct {
+	repository mention.Repository
+	llm        llm.Repository
+	knowledge  knowledge.Repository
+	xdotcom    xdotcom.Repository
+	personas   persona.Repository
+	policy     *policy.Engine
+	config     *configs.Mentions
+}
+
+func NewSync(repository mention.Repository, llm llm.Repository, knowledge knowledge.Repository, xdotcom xdotcom.Repository, personas persona.Repository, engine *policy.Engine, config *configs.Mentions) Sync {
+	return &syncMentions{
+		repository, llm, knowledge, xdotcom, personas, engine, config,
+	}
+}
+
+// Handle stores mentions received since the last sync, classifies them and drafts replies.
+// Confident drafts for questions and praise are approved for the scheduler to send; everything
+// else waits for a person, and spam or hostile mentions are ignored.
+func (service *syncMentions) Handle() (int, error) {
+	sinceID, err := service.repository.LatestTweetID()
+	if err != nil {
+		return 0, err
+	}
+
+	posts, err := service.xdotcom.Mentions(sinceID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Oldest first so an interrupted sync resumes from the right place
+	for i := len(posts) - 1; i >= 0; i-- {
+		m, err := service.process(posts[i])
+		if err != nil {
+			return len(posts) - 1 - i, err
+		}
+		if err = service.repository.AddMention(m); err != nil {
+			return len(posts) - 1 - i, err
+		}
+	}
+	return len(posts), nil
+}
+
+func (service *syncMentions) process(post xdotcom.Post) (*mention.Mention, error) {
+	m := &mention.Mention{
+		TweetID:        post.ID,
+		AuthorID:       post.AuthorID,
+		AuthorUsername: post.AuthorUsername,
+		Text:           post.Text,
+		Status:         mention.StatusPending,
+	}
+
+	response, err := service.llm.Prompt(ClassifyMentionPrompt(post))
+	if err != nil {
+		return nil, err
+	}
+	m.Classification, m.Confidence, err = parseClassification(response)
+	if err != nil {
+		// An unreadable classification still leaves the mention for a person to look at
+		fmt.Println(err)
+		return m, nil
+	}
+
+	if m.Classification == mention.SPAM || m.Classification == mention.HOSTILE {
+		m.Status = mention.StatusIgnored
+		return m, nil
+	}
+
+	mentionEmbedding, err := service.llm.Embed(post.Text)
+	if err != nil {
+		return nil, err
+	}
+	passages, err := service.knowledge.NearestPassages(mentionEmbedding, replyPassageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	voice, err := service.personas.GetPersonaForCategory("")
+	if err != nil {
+		fmt.Println(err)
+		voice = persona.Fallback()
+	}
+	draft, err := service.llm.Prompt(DraftReplyPrompt(post, m.Classification, passages, voice))
+	if err != nil {
+		return nil, err
+	}
+	m.Draft = strings.Trim(strings.TrimSpace(draft), "\"")
+
+	// A draft in the wrong voice is kept for a person to rewrite rather than sent
+	enforced, err := voice.Enforce(m.Draft)
+	if err != nil {
+		fmt.Println(err)
+		return m, nil
+	}
+	m.Draft = enforced
+
+	// A draft the content policy blocks waits for a person like any other
+	reviewed, report, err := service.policy.ReviewText(m.Draft)
+	if err != nil {
+		fmt.Println(err)
+		return m, nil
+	}
+	if report.Verdict == policy.Block {
+		for _, violation := range report.Violations {
+			fmt.Printf("policy %s on reply to %s: %s\n", violation.Verdict, m.TweetID, violation.Reason)
+		}
+		return m, nil
+	}
+	m.Draft = reviewed
+
+	if m.Confidence >= service.config.AutoReplyConfidence && m.Draft != "" && len([]rune(m.Draft)) <= maxReplyLength {
+		m.Status = mention.StatusApproved
+	}
+	return m, nil
+}
+
+type classificationResponse struct {
+	Classification string  `json:"classification"`
+	Confidence     float64 `json:"confidence"`
+}
+
+func parseClassification(input string) (string, float64, error) {
+	input = strings.TrimSpace(input)
+	input = strings.TrimPrefix(input, "```json")
+	input = strings.TrimSuffix(input, "```")
+
+	var response classificationResponse
+	if err := json.Unmarshal([]byte(strings.TrimSpace(input)), &response); err != nil {
+		return "", 0, fmt.Errorf("invalid classification format: %v", err)
+	}
+
+	classification := strings.ToLower(strings.TrimSpace(response.Classification))
+	switch classification {
+	case mention.QUESTION, mention.PRAISE, mention.SPAM, mention.HOSTILE:
+	default:
+		return "", 0, fmt.Errorf("unknown classification %q", response.Classification)
+	}
+
+	confidence := response.Confidence
+	if confidence < 0 {
+		confidence = 0
+	} else if confidence > 1 {
+		confidence = 1
+	}
+	return classification, confidence, nil
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseClassification(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              string
+		wantClassification string
+		wantConfidence     float64
+		wantErr            bool
+	}{
+		{
+			name:               "question",
+			input:              `{"classification": "question", "confidence": 0.92}`,
+			wantClassification: mention.QUESTION,
+			wantConfidence:     0.92,
+		},
+		{
+			name:               "code block and casing",
+			input:              "```json\n{\"classification\": \" Praise\", \"confidence\": 0.7}\n```",
+			wantClassification: mention.PRAISE,
+			wantConfidence:     0.7,
+		},
+		{
+			name:               "confidence is clamped",
+			input:              `{"classification": "spam", "confidence": 7}`,
+			wantClassification: mention.SPAM,
+			wantConfidence:     1,
+		},
+		{
+			name:    "unknown label",
+			input:   `{"classification": "neutral", "confidence": 0.5}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			input:   "question",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classification, confidence, err := parseClassification(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantClassification, classification)
+			assert.Equal(t, tt.wantConfidence, confidence)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package mention
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/internals/services/mention/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/mention/queries"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	Sync    commands.Sync
+	Approve commands.Approve
+	Reject  commands.Reject
+	Reply   commands.Reply
+}
+
+type Queries struct {
+	GetMentions queries.GetMentions
+}
+
+func NewMentionService(repository mention.Repository, llm llm.Repository, knowledge knowledge.Repository, xdotcom xdotcom.Repository, personas persona.Repository, engine *policy.Engine, environmentVariables *configs.EnvironmentVariables) Services {
+	return Services{
+		Commands: Commands{
+			Sync:    commands.NewSync(repository, llm, knowledge, xdotcom, personas, engine, environmentVariables.Mentions),
+			Approve: commands.NewApprove(repository),
+			Reject:  commands.NewReject(repository),
+			Reply:   commands.NewReply(repository, xdotcom, engine),
+		},
+		Queries: Queries{
+			GetMentions: queries.NewGetMentions(repository),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/mention"
+)
+
+type GetMentions interface {
+	Handle(params *mention.GetMentionsParams) ([]mention.Mention, error)
+}
+
+type getMentions struct {
+	repository mention.Repository
+}
+
+func NewGetMentions(repository mention.Repository) GetMentions {
+	return &getMentions{
+		repository,
+	}
+}
+
+func (service *getMentions) Handle(params *mention.GetMentionsParams) ([]mention.Mention, error) {
+	return service.repository.GetMentions(params)
+}
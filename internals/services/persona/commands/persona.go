@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type AddPersona interface {
+	Handle(params *persona.PersonaParams) (*persona.Persona, error)
+}
+
+type addPersona struct {
+	repository persona.Repository
+}
+
+func NewAddPersona(repository persona.Repository) AddPersona {
+	return &addPersona{
+		repository,
+	}
+}
+
+// Handle stores a persona, taking over as the default when it asks to be
+func (service *addPersona) Handle(params *persona.PersonaParams) (*persona.Persona, error) {
+	p := newPersona(params)
+	if err := checkName(service.repository, p); err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.AddPersona(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type UpdatePersona interface {
+	Handle(params *persona.UpdatePersonaParams) (*persona.Persona, error)
+}
+
+type updatePersona struct {
+	repository persona.Repository
+}
+
+func NewUpdatePersona(repository persona.Repository) UpdatePersona {
+	return &updatePersona{
+		repository,
+	}
+}
+
+// Handle replaces a persona. The default persona can only stop being the
+// default by another persona taking its place
+func (service *updatePersona) Handle(params *persona.UpdatePersonaParams) (*persona.Persona, error) {
+	current, err := service.repository.GetPersona(params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsDefault && !params.IsDefault {
+		return nil, appError.Conflict(errors.New("make another persona the default instead"))
+	}
+
+	p := newPersona(&params.PersonaParams)
+	p.ID = current.ID
+	p.CreatedAt = current.CreatedAt
+	if err = checkName(service.repository, p); err != nil {
+		return nil, err
+	}
+
+	if err = service.repository.UpdatePersona(p); err != nil {
+		return nil, err
+	}
+	return service.repository.GetPersona(p.ID)
+}
+
+type DeletePersona interface {
+	Handle(id int) error
+}
+
+type deletePersona struct {
+	repository persona.Repository
+}
+
+func NewDeletePersona(repository persona.Repository) DeletePersona {
+	return &deletePersona{
+		repository,
+	}
+}
+
+// Handle removes a persona. Its categories fall back to the default persona,
+// which itself cannot be removed
+func (service *deletePersona) Handle(id int) error {
+	p, err := service.repository.GetPersona(id)
+	if err != nil {
+		return err
+	}
+	if p.IsDefault {
+		return appError.Conflict(errors.New("the default persona cannot be deleted"))
+	}
+	return service.repository.DeletePersona(id)
+}
+
+func newPersona(params *persona.PersonaParams) *persona.Persona {
+	return &persona.Persona{
+		Name:             strings.TrimSpace(params.Name),
+		Description:      strings.TrimSpace(params.Description),
+		Tone:             strings.TrimSpace(params.Tone),
+		ReadingLevel:     strings.TrimSpace(params.ReadingLevel),
+		BannedWords:      clean(params.BannedWords),
+		MaxHashtags:      params.MaxHashtags,
+		MaxEmojis:        params.MaxEmojis,
+		SignaturePhrases: clean(params.SignaturePhrases),
+		ExamplePosts:     clean(params.ExamplePosts),
+		Categories:       clean(params.Categories),
+		IsDefault:        params.IsDefault,
+	}
+}
+
+// checkName makes sure no other persona already uses the name
+func checkName(repository persona.Repository, p *persona.Persona) error {
+	personas, err := repository.GetPersonas()
+	if err != nil {
+		return err
+	}
+	for _, existing := range personas {
+		if existing.ID != p.ID && strings.EqualFold(existing.Name, p.Name) {
+			return appError.Conflict(fmt.Errorf("persona %q already exists", p.Name))
+		}
+	}
+	return nil
+}
+
+// clean trims every value and drops the empty ones
+func clean(values []string) []string {
+	cleaned := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			cleaned = append(cleaned, value)
+		}
+	}
+	return cleaned
+}
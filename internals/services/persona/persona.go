@@ -0,0 +1,35 @@
+package persona
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/services/persona/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/persona/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	AddPersona    commands.AddPersona
+	UpdatePersona commands.UpdatePersona
+	DeletePersona commands.DeletePersona
+}
+
+type Queries struct {
+	GetPersonas queries.GetPersonas
+}
+
+func NewPersonaService(repository persona.Repository) Services {
+	return Services{
+		Commands: Commands{
+			AddPersona:    commands.NewAddPersona(repository),
+			UpdatePersona: commands.NewUpdatePersona(repository),
+			DeletePersona: commands.NewDeletePersona(repository),
+		},
+		Queries: Queries{
+			GetPersonas: queries.NewGetPersonas(repository),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+)
+
+type GetPersonas interface {
+	Handle() ([]persona.Persona, error)
+}
+
+type getPersonas struct {
+	repository persona.Repository
+}
+
+func NewGetPersonas(repository persona.Repository) GetPersonas {
+	return &getPersonas{
+		repository,
+	}
+}
+
+func (service *getPersonas) Handle() ([]persona.Persona, error) {
+	return service.repository.GetPersonas()
+}
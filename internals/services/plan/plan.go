@@ -0,0 +1,28 @@
+package plan
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/internals/services/plan/queries"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+}
+
+type Queries struct {
+	GetPlan queries.GetPlan
+}
+
+func NewPlanService(repository plan.Repository, environmentVariables *configs.EnvironmentVariables) Services {
+	return Services{
+		Commands: Commands{},
+		Queries: Queries{
+			GetPlan: queries.NewGetPlan(repository, environmentVariables),
+		},
+	}
+}
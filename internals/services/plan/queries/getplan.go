@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+type GetPlan interface {
+	Handle(params *plan.GetPlanParams) (*plan.Plan, error)
+}
+
+type getPlan struct {
+	repository           plan.Repository
+	environmentVariables *configs.EnvironmentVariables
+}
+
+func NewGetPlan(repository plan.Repository, environmentVariables *configs.EnvironmentVariables) GetPlan {
+	return &getPlan{
+		repository,
+		environmentVariables,
+	}
+}
+
+// Handle returns the plan of a day, today in the schedule's timezone by default
+func (service *getPlan) Handle(params *plan.GetPlanParams) (*plan.Plan, error) {
+	date := params.Date
+	if date == "" {
+		location, err := time.LoadLocation(service.environmentVariables.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		date = time.Now().In(location).Format(time.DateOnly)
+	}
+	return service.repository.GetPlan(date)
+}
@@ -0,0 +1,27 @@
+package policy
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/services/policy/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+}
+
+type Queries struct {
+	GetBlocked queries.GetBlocked
+}
+
+func NewPolicyService(repository policy.Repository) Services {
+	return Services{
+		Commands: Commands{},
+		Queries: Queries{
+			GetBlocked: queries.NewGetBlocked(repository),
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+)
+
+const defaultBlockedLimit = 20
+
+type GetBlocked interface {
+	Handle(params *policy.GetBlockedParams) ([]policy.Blocked, error)
+}
+
+type getBlocked struct {
+	repository policy.Repository
+}
+
+func NewGetBlocked(repository policy.Repository) GetBlocked {
+	return &getBlocked{
+		repository,
+	}
+}
+
+// Handle returns the most recently blocked drafts and why they were blocked
+func (service *getBlocked) Handle(params *policy.GetBlockedParams) ([]policy.Blocked, error) {
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultBlockedLimit
+	}
+	return service.repository.GetBlocked(limit)
+}
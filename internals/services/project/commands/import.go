@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// csvColumns are the columns an import may have. Only name is required;
+// a missing status means the project is live
+var csvColumns = []string{"name", "category", "website", "x_handle", "status", "description"}
+
+type ImportProjects interface {
+	Handle(file io.Reader) (int, error)
+}
+
+type importProjects struct {
+	repository project.Repository
+}
+
+func NewImportProjects(repository project.Repository) ImportProjects {
+	return &importProjects{
+		repository,
+	}
+}
+
+// Handle registers every project in a CSV file with a header row. Projects
+// already registered under the same name are updated. Nothing is imported
+// when any row is invalid
+func (service *importProjects) Handle(file io.Reader) (int, error) {
+	projects, err := ParseCSV(file)
+	if err != nil {
+		return 0, appError.BadRequest(err)
+	}
+	if len(projects) == 0 {
+		return 0, appError.BadRequest(errors.New("no projects to import"))
+	}
+	return service.repository.ImportProjects(projects)
+}
+
+// ParseCSV reads projects from CSV, matching columns by their header
+func ParseCSV(file io.Reader) ([]project.Project, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := index["name"]; !ok {
+		return nil, errors.New("header has no name column")
+	}
+	for column := range index {
+		if !isColumn(column) {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	var projects []project.Project
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		params := project.ProjectParams{
+			Name:        field("name"),
+			Category:    field("category"),
+			Website:     field("website"),
+			XHandle:     field("x_handle"),
+			Status:      strings.ToLower(strings.TrimSpace(field("status"))),
+			Description: field("description"),
+		}
+		if params.Status == "" {
+			params.Status = project.StatusLive
+		}
+
+		p := newProject(&params)
+		switch {
+		case p.Name == "":
+			return nil, fmt.Errorf("line %d: name is required", line)
+		case p.Status != project.StatusLive && p.Status != project.StatusTestnet && p.Status != project.StatusSunset:
+			return nil, fmt.Errorf("line %d: status must be live, testnet or sunset", line)
+		}
+		// The columns' lengths are checked here, as the API binding checks
+		// them, so a long value fails its line rather than the import
+		for _, limit := range []struct {
+			column string
+			value  string
+			max    int
+		}{
+			{"name", p.Name, 128},
+			{"category", p.Category, 64},
+			{"website", p.Website, 256},
+			{"x_handle", p.XHandle, 64},
+			{"description", p.Description, 2000},
+		} {
+			if len([]rune(limit.value)) > limit.max {
+				return nil, fmt.Errorf("line %d: %s is longer than %d characters", line, limit.column, limit.max)
+			}
+		}
+		projects = append(projects, *p)
+	}
+	return projects, nil
+}
+
+func isColumn(column string) bool {
+	for _, known := range csvColumns {
+		if column == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []project.Project
+		wantErr string
+	}{
+		{
+			name: "columns in any order",
+			file: "status,name,x_handle,description\n" +
+				"testnet, Acala ,@AcalaNetwork,\"DeFi hub, stablecoin\"\n" +
+				",Moonbeam,MoonbeamNetwork,\n",
+			want: []project.Project{
+				{Name: "Acala", XHandle: "AcalaNetwork", Status: project.StatusTestnet, Description: "DeFi hub, stablecoin"},
+				{Name: "Moonbeam", XHandle: "MoonbeamNetwork", Status: project.StatusLive},
+			},
+		},
+		{name: "empty file", file: "", wantErr: "file is empty"},
+		{name: "no name column", file: "category,status\nDeFi,live\n", wantErr: "header has no name column"},
+		{name: "unknown column", file: "name,tvl\nAcala,1\n", wantErr: `unknown column "tvl"`},
+		{name: "missing name", file: "name,status\nAcala,live\n ,live\n", wantErr: "line 3: name is required"},
+		{name: "invalid status", file: "name,status\nAcala,retired\n", wantErr: "line 2: status must be live, testnet or sunset"},
+		{name: "long name", file: "name\nAcala\n" + strings.Repeat("a", 129) + "\n", wantErr: "line 3: name is longer than 128 characters"},
+		{name: "long handle", file: "name,x_handle\nAcala,@" + strings.Repeat("a", 65) + "\n", wantErr: "line 2: x_handle is longer than 64 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV(strings.NewReader(tt.file))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
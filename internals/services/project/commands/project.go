@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+type AddProject interface {
+	Handle(params *project.ProjectParams) (*project.Project, error)
+}
+
+type addProject struct {
+	repository project.Repository
+}
+
+func NewAddProject(repository project.Repository) AddProject {
+	return &addProject{
+		repository,
+	}
+}
+
+func (service *addProject) Handle(params *project.ProjectParams) (*project.Project, error) {
+	p := newProject(params)
+	if err := checkName(service.repository, p); err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.AddProject(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type UpdateProject interface {
+	Handle(params *project.UpdateProjectParams) (*project.Project, error)
+}
+
+type updateProject struct {
+	repository project.Repository
+}
+
+func NewUpdateProject(repository project.Repository) UpdateProject {
+	return &updateProject{
+		repository,
+	}
+}
+
+func (service *updateProject) Handle(params *project.UpdateProjectParams) (*project.Project, error) {
+	p := newProject(&params.ProjectParams)
+	p.ID = params.ID
+	if err := checkName(service.repository, p); err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.UpdateProject(p); err != nil {
+		return nil, err
+	}
+	return service.repository.GetProject(p.ID)
+}
+
+type DeleteProject interface {
+	Handle(id int) error
+}
+
+type deleteProject struct {
+	repository project.Repository
+}
+
+func NewDeleteProject(repository project.Repository) DeleteProject {
+	return &deleteProject{
+		repository,
+	}
+}
+
+// Handle removes a project. Topics already queued for it stay in the backlog
+func (service *deleteProject) Handle(id int) error {
+	return service.repository.DeleteProject(id)
+}
+
+func newProject(params *project.ProjectParams) *project.Project {
+	return &project.Project{
+		Name:        strings.TrimSpace(params.Name),
+		Category:    strings.TrimSpace(params.Category),
+		Website:     strings.TrimSpace(params.Website),
+		XHandle:     strings.TrimPrefix(strings.TrimSpace(params.XHandle), "@"),
+		Status:      params.Status,
+		Description: strings.TrimSpace(params.Description),
+	}
+}
+
+// checkName makes sure no other project is already registered under the name
+func checkName(repository project.Repository, p *project.Project) error {
+	projects, err := repository.GetProjects()
+	if err != nil {
+		return err
+	}
+	for _, existing := range projects {
+		if existing.ID != p.ID && strings.EqualFold(existing.Name, p.Name) {
+			return appError.Conflict(fmt.Errorf("project %q already exists", p.Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package project
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/services/project/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/project/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	AddProject     commands.AddProject
+	UpdateProject  commands.UpdateProject
+	DeleteProject  commands.DeleteProject
+	ImportProjects commands.ImportProjects
+}
+
+type Queries struct {
+	GetProjects queries.GetProjects
+}
+
+func NewProjectService(repository project.Repository) Services {
+	return Services{
+		Commands: Commands{
+			AddProject:     commands.NewAddProject(repository),
+			UpdateProject:  commands.NewUpdateProject(repository),
+			DeleteProject:  commands.NewDeleteProject(repository),
+			ImportProjects: commands.NewImportProjects(repository),
+		},
+		Queries: Queries{
+			GetProjects: queries.NewGetProjects(repository),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+)
+
+type GetProjects interface {
+	Handle() ([]project.Project, error)
+}
+
+type getProjects struct {
+	repository project.Repository
+}
+
+func NewGetProjects(repository project.Repository) GetProjects {
+	return &getProjects{
+		repository,
+	}
+}
+
+func (service *getProjects) Handle() ([]project.Project, error) {
+	return service.repository.GetProjects()
+}
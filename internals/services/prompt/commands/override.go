@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+)
+
+type OverrideTemplate interface {
+	Handle(params *prompt.OverrideTemplateParams) (*prompt.Template, error)
+}
+
+type overrideTemplate struct {
+	repository prompt.Repository
+}
+
+func NewOverrideTemplate(repository prompt.Repository) OverrideTemplate {
+	return &overrideTemplate{
+		repository,
+	}
+}
+
+// Handle stores a new version of a template and makes it the one generation uses
+func (service *overrideTemplate) Handle(params *prompt.OverrideTemplateParams) (*prompt.Template, error) {
+	return service.repository.OverrideTemplate(params.Name, params.Body)
+}
+
+type ResetTemplate interface {
+	Handle(name string) error
+}
+
+type resetTemplate struct {
+	repository prompt.Repository
+}
+
+func NewResetTemplate(repository prompt.Repository) ResetTemplate {
+	return &resetTemplate{
+		repository,
+	}
+}
+
+// Handle goes back to the newest template file for a template
+func (service *resetTemplate) Handle(name string) error {
+	return service.repository.ResetTemplate(name)
+}
@@ -0,0 +1,33 @@
+package prompt
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/services/prompt/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/prompt/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	OverrideTemplate commands.OverrideTemplate
+	ResetTemplate    commands.ResetTemplate
+}
+
+type Queries struct {
+	GetTemplates queries.GetTemplates
+}
+
+func NewPromptService(repository prompt.Repository) Services {
+	return Services{
+		Commands: Commands{
+			OverrideTemplate: commands.NewOverrideTemplate(repository),
+			ResetTemplate:    commands.NewResetTemplate(repository),
+		},
+		Queries: Queries{
+			GetTemplates: queries.NewGetTemplates(repository),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+)
+
+type GetTemplates interface {
+	Handle() ([]prompt.Template, error)
+}
+
+type getTemplates struct {
+	repository prompt.Repository
+}
+
+func NewGetTemplates(repository prompt.Repository) GetTemplates {
+	return &getTemplates{
+		repository,
+	}
+}
+
+func (service *getTemplates) Handle() ([]prompt.Template, error) {
+	return service.repository.GetTemplates()
+}
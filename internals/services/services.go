@@ -2,18 +2,62 @@ package services
 
 import (
 	"github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters"
+	"github.com/Pr3c10us/boilerplate/internals/services/analytics"
 	"github.com/Pr3c10us/boilerplate/internals/services/authentication"
+	"github.com/Pr3c10us/boilerplate/internals/services/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/services/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/services/health"
+	"github.com/Pr3c10us/boilerplate/internals/services/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/services/mention"
+	"github.com/Pr3c10us/boilerplate/internals/services/persona"
+	"github.com/Pr3c10us/boilerplate/internals/services/plan"
+	"github.com/Pr3c10us/boilerplate/internals/services/policy"
+	"github.com/Pr3c10us/boilerplate/internals/services/project"
+	"github.com/Pr3c10us/boilerplate/internals/services/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/services/topic"
 	"github.com/Pr3c10us/boilerplate/internals/services/tweet"
+	"github.com/Pr3c10us/boilerplate/internals/services/tweet/command"
 )
 
 type Services struct {
 	AuthenticationServices authentication.Services
 	TweetService           tweet.Services
+	KnowledgeService       knowledge.Services
+	MentionService         mention.Services
+	AnalyticsService       analytics.Services
+	ExperimentService      experiment.Services
+	PromptService          prompt.Services
+	PersonaService         persona.Services
+	PolicyService          policy.Services
+	TopicService           topic.Services
+	ProjectService         project.Services
+	PlanService            plan.Services
+	EmbeddingService       embedding.Services
+	HealthService          health.Services
 }
 
 func NewServices(adapters *adapters.Adapters) *Services {
+	// One content policy reviews everything published: tweets, quote
+	// commentary and mention replies
+	engine, err := command.NewPolicyEngine(adapters.OpenAiRepository, adapters.EnvironmentVariables.Policy)
+	if err != nil {
+		panic(err)
+	}
+
 	return &Services{
 		AuthenticationServices: authentication.NewAuthenticationService(adapters.EmailRepository, adapters.CacheRepository, adapters.EnvironmentVariables, adapters.AuthenticationRepository),
-		TweetService:           tweet.NewTweetService(adapters.OpenAiRepository, adapters.EmbeddingRepository, adapters.XDotComRepository),
+		TweetService:           tweet.NewTweetService(adapters.OpenAiRepository, adapters.JudgeRepository, adapters.TopicRepository, adapters.ProjectRepository, adapters.XDotComRepository, adapters.KnowledgeRepository, adapters.PostRepository, adapters.EmbeddingRepository, adapters.ExperimentRepository, adapters.PromptRepository, adapters.PersonaRepository, engine, adapters.PolicyRepository, adapters.PlanRepository, adapters.EnvironmentVariables),
+		KnowledgeService:       knowledge.NewKnowledgeService(adapters.OpenAiRepository, adapters.KnowledgeRepository),
+		MentionService:         mention.NewMentionService(adapters.MentionRepository, adapters.OpenAiRepository, adapters.KnowledgeRepository, adapters.XDotComRepository, adapters.PersonaRepository, engine, adapters.EnvironmentVariables),
+		AnalyticsService:       analytics.NewAnalyticsService(adapters.PostRepository, adapters.XDotComRepository, adapters.EnvironmentVariables),
+		ExperimentService:      experiment.NewExperimentService(adapters.ExperimentRepository),
+		PromptService:          prompt.NewPromptService(adapters.PromptRepository),
+		PersonaService:         persona.NewPersonaService(adapters.PersonaRepository),
+		PolicyService:          policy.NewPolicyService(adapters.PolicyRepository),
+		TopicService:           topic.NewTopicService(adapters.TopicRepository, adapters.OpenAiRepository),
+		ProjectService:         project.NewProjectService(adapters.ProjectRepository),
+		PlanService:            plan.NewPlanService(adapters.PlanRepository, adapters.EnvironmentVariables),
+		EmbeddingService:       embedding.NewEmbeddingService(adapters.EmbeddingRepository, adapters.Embedder, adapters.EmbeddingCache, adapters.EnvironmentVariables),
+		HealthService:          health.NewHealthService(adapters.HealthCheckers, adapters.EnvironmentVariables),
 	}
 }
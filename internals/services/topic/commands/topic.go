@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	"github.com/Pr3c10us/boilerplate/packages/appError"
+)
+
+// adminSource tags topics added through the API
+const adminSource = "admin"
+
+type AddTopic interface {
+	Handle(params *topic.AddTopicParams) (*topic.Topic, error)
+}
+
+type addTopic struct {
+	repository topic.Repository
+	llm        llm.Repository
+}
+
+func NewAddTopic(repository topic.Repository, llm llm.Repository) AddTopic {
+	return &addTopic{
+		repository, llm,
+	}
+}
+
+// Handle queues a hand-picked topic unless a similar one was queued or tweeted before
+func (service *addTopic) Handle(params *topic.AddTopicParams) (*topic.Topic, error) {
+	t := &topic.Topic{
+		Text:      strings.TrimSpace(params.Text),
+		TopicType: params.TopicType,
+		Category:  strings.TrimSpace(params.Category),
+		Source:    adminSource,
+		Priority:  params.Priority,
+	}
+
+	embedding, err := service.llm.Embed(t.Text)
+	if err != nil {
+		return nil, err
+	}
+	added, err := service.repository.AddTopic(t, embedding)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, appError.Conflict(errors.New("a similar topic was already queued or tweeted"))
+	}
+	return t, nil
+}
+
+type UpdatePriority interface {
+	Handle(params *topic.UpdatePriorityParams) error
+}
+
+type updatePriority struct {
+	repository topic.Repository
+}
+
+func NewUpdatePriority(repository topic.Repository) UpdatePriority {
+	return &updatePriority{
+		repository,
+	}
+}
+
+func (service *updatePriority) Handle(params *topic.UpdatePriorityParams) error {
+	return service.repository.UpdatePriority(params.ID, params.Priority)
+}
+
+type DeleteTopic interface {
+	Handle(id int) error
+}
+
+type deleteTopic struct {
+	repository topic.Repository
+}
+
+func NewDeleteTopic(repository topic.Repository) DeleteTopic {
+	return &deleteTopic{
+		repository,
+	}
+}
+
+func (service *deleteTopic) Handle(id int) error {
+	return service.repository.DeleteTopic(id)
+}
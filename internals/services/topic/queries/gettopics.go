@@ -0,0 +1,23 @@
+package queries
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+)
+
+type GetTopics interface {
+	Handle(params *topic.GetTopicsParams) ([]topic.Topic, error)
+}
+
+type getTopics struct {
+	repository topic.Repository
+}
+
+func NewGetTopics(repository topic.Repository) GetTopics {
+	return &getTopics{
+		repository,
+	}
+}
+
+func (service *getTopics) Handle(params *topic.GetTopicsParams) ([]topic.Topic, error) {
+	return service.repository.GetTopics(params)
+}
@@ -0,0 +1,36 @@
+package topic
+
+import (
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	"github.com/Pr3c10us/boilerplate/internals/services/topic/commands"
+	"github.com/Pr3c10us/boilerplate/internals/services/topic/queries"
+)
+
+type Services struct {
+	Commands
+	Queries
+}
+
+type Commands struct {
+	AddTopic       commands.AddTopic
+	UpdatePriority commands.UpdatePriority
+	DeleteTopic    commands.DeleteTopic
+}
+
+type Queries struct {
+	GetTopics queries.GetTopics
+}
+
+func NewTopicService(repository topic.Repository, llm llm.Repository) Services {
+	return Services{
+		Commands: Commands{
+			AddTopic:       commands.NewAddTopic(repository, llm),
+			UpdatePriority: commands.NewUpdatePriority(repository),
+			DeleteTopic:    commands.NewDeleteTopic(repository),
+		},
+		Queries: Queries{
+			GetTopics: queries.NewGetTopics(repository),
+		},
+	}
+}
@@ -0,0 +1,257 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/google/uuid"
+)
+
+const (
+	minTweetCharacters = 80
+	maxSentenceWords   = 40
+	easySentenceWords  = 20
+	hookWords          = 12
+)
+
+var (
+	sentenceEnd = regexp.MustCompile(`[.!?]+(\s|$)`)
+	hookOpeners = []string{"how", "why", "what", "stop", "most", "here's", "you", "imagine", "forget", "the truth"}
+)
+
+// BestCandidate generates candidates for a prompt concurrently, scores them
+// and returns the best one. Every candidate is stored under the draft's batch
+func (service *Tweet) BestCandidate(draft *post.Draft, prompt string, voice *persona.Persona) (*post.Candidate, error) {
+	count := max(service.candidates.Count, 1)
+	results := make([]*post.Candidate, count)
+	errs := make([]error, count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = service.Candidate(draft, prompt, voice, count > 1)
+		}(i)
+	}
+	wg.Wait()
+
+	var candidates []post.Candidate
+	var firstErr error
+	for i, candidate := range results {
+		if errs[i] != nil {
+			fmt.Println(errs[i])
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		candidates = append(candidates, *candidate)
+	}
+	if len(candidates) == 0 {
+		return nil, firstErr
+	}
+
+	best := 0
+	for i := range candidates {
+		if candidates[i].Score > candidates[best].Score {
+			best = i
+		}
+	}
+	candidates[best].Selected = true
+
+	draft.CandidateBatch = uuid.NewString()
+	for i := range candidates {
+		candidates[i].Batch = draft.CandidateBatch
+	}
+	// Candidates are kept for analysis only, so losing them doesn't stop the slot
+	if err := service.post.AddCandidates(candidates); err != nil {
+		fmt.Println(err)
+	}
+	return &candidates[best], nil
+}
+
+// Candidate generates one draft from the prompt and scores it. The judge is
+// only asked when there is more than one candidate to choose between
+func (service *Tweet) Candidate(draft *post.Draft, prompt string, voice *persona.Persona, judge bool) (*post.Candidate, error) {
+	response, err := service.llm.Prompt(prompt)
+	if err != nil {
+		return nil, err
+	}
+	tweets, err := service.ParseResponse(draft.Format, response, voice)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &post.Candidate{
+		Topic:          draft.Topic,
+		Format:         draft.Format,
+		PromptTemplate: draft.PromptTemplate,
+		Tweets:         tweets,
+	}
+	for _, tweet := range tweets {
+		candidate.Texts = append(candidate.Texts, tweet.Text)
+		if tweet.Poll != nil {
+			candidate.Texts = append(candidate.Texts, tweet.Poll.Options...)
+		}
+	}
+	candidate.Heuristics, candidate.Heuristic = Heuristics(tweets, voice.MaxHashtags)
+	candidate.Score = candidate.Heuristic
+
+	if !judge {
+		return candidate, nil
+	}
+	score, reason, err := service.Judge(draft, candidate.Texts)
+	if err != nil {
+		// An unjudged candidate still competes on its heuristics
+		fmt.Println(err)
+		return candidate, nil
+	}
+	candidate.Judge = &score
+	candidate.JudgeReason = reason
+	weight := service.candidates.JudgeWeight
+	candidate.Score = (1-weight)*candidate.Heuristic + weight*score
+	return candidate, nil
+}
+
+type judgeScoreResponse struct {
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// Judge scores a candidate against the rubric, between 0 and 1
+func (service *Tweet) Judge(draft *post.Draft, texts []string) (float64, string, error) {
+	response, err := service.judge.Prompt(JudgeCandidatePrompt(draft.Topic, draft.Format, service.candidates.Rubric, texts))
+	if err != nil {
+		return 0, "", err
+	}
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimSuffix(response, "```")
+
+	var judgement judgeScoreResponse
+	if err = json.Unmarshal([]byte(strings.TrimSpace(response)), &judgement); err != nil {
+		return 0, "", fmt.Errorf("invalid judge score format: %v", err)
+	}
+	if judgement.Score < 1 || judgement.Score > 10 {
+		return 0, "", fmt.Errorf("judge score %v is outside 1 to 10", judgement.Score)
+	}
+	return (judgement.Score - 1) / 9, strings.TrimSpace(judgement.Reason), nil
+}
+
+// Heuristics scores what can be measured about tweets without a model, each
+// between 0 and 1, and returns the scores with their average
+func Heuristics(tweets []xdotcom.Tweet, maxHashtags int) (map[string]float64, float64) {
+	texts := make([]string, len(tweets))
+	for i, tweet := range tweets {
+		texts[i] = tweet.Text
+	}
+
+	scores := map[string]float64{
+		"length":      lengthFit(texts),
+		"readability": readability(texts),
+		"hook":        0,
+		"hashtags":    hashtagFit(texts, maxHashtags),
+	}
+	if len(texts) > 0 {
+		scores["hook"] = hookStrength(texts[0])
+	}
+
+	total := 0.0
+	for _, score := range scores {
+		total += score
+	}
+	return scores, total / float64(len(scores))
+}
+
+// lengthFit favours tweets that use the room they have without running over
+func lengthFit(texts []string) float64 {
+	if len(texts) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, text := range texts {
+		length := len([]rune(text))
+		switch {
+		case length > MaxTweetLength:
+		case length > TweetCharacters:
+			total += 0.5
+		case length < minTweetCharacters:
+			total += float64(length) / minTweetCharacters
+		default:
+			total++
+		}
+	}
+	return total / float64(len(texts))
+}
+
+// readability falls from 1 to 0 as the average sentence grows from easy to too long
+func readability(texts []string) float64 {
+	sentences, words := 0, 0
+	for _, text := range texts {
+		for _, sentence := range sentenceEnd.Split(text, -1) {
+			if count := len(strings.Fields(sentence)); count > 0 {
+				sentences++
+				words += count
+			}
+		}
+	}
+	if sentences == 0 {
+		return 0
+	}
+	average := float64(words) / float64(sentences)
+	switch {
+	case average <= easySentenceWords:
+		return 1
+	case average >= maxSentenceWords:
+		return 0
+	default:
+		return (maxSentenceWords - average) / (maxSentenceWords - easySentenceWords)
+	}
+}
+
+// hookStrength rewards an opening line that is short, concrete and makes people curious
+func hookStrength(text string) float64 {
+	first := strings.TrimSpace(text)
+	if loc := sentenceEnd.FindStringIndex(first); loc != nil {
+		first = strings.TrimSpace(first[:loc[1]])
+	}
+	if first == "" {
+		return 0
+	}
+
+	signals := 0.0
+	if len(strings.Fields(first)) <= hookWords {
+		signals++
+	}
+	if strings.IndexFunc(first, unicode.IsDigit) >= 0 {
+		signals++
+	}
+	lower := strings.ToLower(first)
+	curious := strings.HasSuffix(first, "?")
+	for _, opener := range hookOpeners {
+		if strings.HasPrefix(lower, opener+" ") {
+			curious = true
+		}
+	}
+	if curious {
+		signals++
+	}
+	return signals / 3
+}
+
+// hashtagFit loses half a point for every hashtag over the persona's limit
+func hashtagFit(texts []string, maxHashtags int) float64 {
+	count := 0
+	for _, text := range texts {
+		count += len(persona.HashtagPattern.FindAllString(text, -1))
+	}
+	return max(0, 1-0.5*float64(max(0, count-maxHashtags)))
+}
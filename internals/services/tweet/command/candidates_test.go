@@ -0,0 +1,165 @@
+package command
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// queueLLM hands out its replies in order, one per prompt, and keeps the prompts
+type queueLLM struct {
+	mutex   sync.Mutex
+	replies []string
+	prompts []string
+}
+
+func (l *queueLLM) Prompt(prompt string) (string, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.prompts = append(l.prompts, prompt)
+	reply := l.replies[0]
+	l.replies = l.replies[1:]
+	return reply, nil
+}
+
+func (l *queueLLM) Embed(prompt string) ([]float32, error) {
+	return nil, nil
+}
+
+// rubricJudge scores a draft by the first score whose keyword it contains
+type rubricJudge struct {
+	scores map[string]string
+}
+
+func (j *rubricJudge) Prompt(prompt string) (string, error) {
+	for keyword, score := range j.scores {
+		if strings.Contains(prompt, keyword) {
+			return `{"score": ` + score + `, "reason": "` + keyword + `"}`, nil
+		}
+	}
+	return `{"score": 1, "reason": "unknown"}`, nil
+}
+
+func (j *rubricJudge) Embed(prompt string) ([]float32, error) {
+	return nil, nil
+}
+
+type candidateStore struct {
+	candidates []post.Candidate
+}
+
+func (s *candidateStore) AddPost(params *post.Post) error { return nil }
+func (s *candidateStore) AddCandidates(candidates []post.Candidate) error {
+	s.candidates = append(s.candidates, candidates...)
+	return nil
+}
+func (s *candidateStore) GetPostsSince(since time.Time) ([]post.Post, error) { return nil, nil }
+func (s *candidateStore) AddSnapshots(snapshots []post.Snapshot) error       { return nil }
+func (s *candidateStore) GetPerformance(params *post.GetPerformanceParams) ([]post.Performance, error) {
+	return nil, nil
+}
+func (s *candidateStore) GetHourlyEngagement(params *post.GetSlotWeightsParams) ([]post.HourlyEngagement, error) {
+	return nil, nil
+}
+
+func TestHeuristics(t *testing.T) {
+	tests := []struct {
+		name   string
+		texts  []string
+		scores map[string]float64
+	}{
+		{
+			name:  "strong short tweet",
+			texts: []string{"Why do 50 parachains share one security budget? Because the relay chain validates every block they produce, so a new chain never has to bootstrap its own validators."},
+			scores: map[string]float64{
+				"length": 1, "readability": 1, "hook": 1, "hashtags": 1,
+			},
+		},
+		{
+			name:  "flat opener and too many hashtags",
+			texts: []string{"Polkadot is a multichain network that lets specialised blockchains called parachains share security and pass messages to each other #Polkadot #DOT"},
+			scores: map[string]float64{
+				"length": 1, "readability": 0.95, "hook": 0, "hashtags": 0.5,
+			},
+		},
+		{
+			name:  "thread with a short and a too long tweet",
+			texts: []string{"XCM explained.", strings.Repeat("word ", 60)},
+			scores: map[string]float64{
+				"length": 14.0 / 80 / 2, "readability": 0.45, "hook": 1.0 / 3, "hashtags": 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tweets := make([]xdotcom.Tweet, len(tt.texts))
+			for i, text := range tt.texts {
+				tweets[i] = xdotcom.Tweet{Text: text}
+			}
+			scores, average := Heuristics(tweets, 1)
+			for name, want := range tt.scores {
+				assert.InDelta(t, want, scores[name], 0.001, name)
+			}
+			assert.InDelta(t, (scores["length"]+scores["readability"]+scores["hook"]+scores["hashtags"])/4, average, 0.001)
+		})
+	}
+}
+
+func TestTweet_BestCandidate(t *testing.T) {
+	store := &candidateStore{}
+	service := &Tweet{
+		llm: &queueLLM{replies: []string{
+			"Polkadot has parachains.",
+			"Why do parachains never need their own validators? The relay chain secures all of them at once.",
+			"Shared security explained",
+		}},
+		judge:      &rubricJudge{scores: map[string]string{"never need": "9", "has parachains": "3", "explained": "5"}},
+		post:       store,
+		candidates: &configs.Candidates{Count: 3, JudgeWeight: 0.6},
+	}
+
+	draft := &post.Draft{Topic: "shared security", Format: SHORT}
+	best, err := service.BestCandidate(draft, "prompt", &persona.Persona{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Why do parachains never need their own validators? The relay chain secures all of them at once."}, best.Texts)
+	assert.True(t, best.Selected)
+	assert.NotEmpty(t, draft.CandidateBatch)
+
+	assert.Len(t, store.candidates, 3)
+	selected := 0
+	for _, candidate := range store.candidates {
+		assert.Equal(t, draft.CandidateBatch, candidate.Batch)
+		assert.NotNil(t, candidate.Judge)
+		if candidate.Selected {
+			selected++
+		}
+	}
+	assert.Equal(t, 1, selected)
+}
+
+func TestTweet_BestCandidate_SkipsFailures(t *testing.T) {
+	store := &candidateStore{}
+	service := &Tweet{
+		llm:        &queueLLM{replies: []string{"not a poll", `{"question": "Which XCM feature matters most?", "options": ["Teleports", "Reserve transfers"]}`}},
+		judge:      &rubricJudge{},
+		post:       store,
+		candidates: &configs.Candidates{Count: 2, JudgeWeight: 0.6},
+	}
+
+	best, err := service.BestCandidate(&post.Draft{Topic: "XCM", Format: POLL}, "prompt", &persona.Persona{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Which XCM feature matters most?", "Teleports", "Reserve transfers"}, best.Texts)
+	assert.Len(t, store.candidates, 1)
+
+	service.llm = &queueLLM{replies: []string{"not a poll"}}
+	service.candidates.Count = 1
+	_, err = service.BestCandidate(&post.Draft{Topic: "XCM", Format: POLL}, "prompt", &persona.Persona{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+const (
+	QUOTE   = "quote"
+	RETWEET = "retweet"
+	SKIP    = "skip"
+)
+
+const curationPassageLimit = 3
+
+type Curate struct {
+	llm       llm.Repository
+	knowledge knowledge.Repository
+	xdotcom   xdotcom.Repository
+	personas  persona.Repository
+	policy    *policy.Engine
+	blocked   policy.Repository
+	config    *configs.Curation
+}
+
+func NewCurate(llm llm.Repository, knowledge knowledge.Repository, xdotcom xdotcom.Repository, personas persona.Repository, engine *policy.Engine, blocked policy.Repository, config *configs.Curation) *Curate {
+	return &Curate{llm: llm, knowledge: knowledge, xdotcom: xdotcom, personas: personas, policy: engine, blocked: blocked, config: config}
+}
+
+// Curation is a decision about an ecosystem post: quote it with commentary, retweet it, or leave it
+type Curation struct {
+	Post       xdotcom.Post `json:"post"`
+	Score      float64      `json:"score"`
+	Action     string       `json:"action"`
+	Commentary string       `json:"commentary,omitempty"`
+}
+
+// Candidates scores posts an account made after sinceID and drafts commentary for the ones worth quoting.
+// Posts come back oldest first so callers can advance their since ID as they publish. Scoring stops
+// once limit posts are worth publishing, so posts past the cap are left for a later run unevaluated.
+func (service *Curate) Candidates(account, sinceID string, limit int) ([]Curation, error) {
+	posts, err := service.xdotcom.UserTimeline(account, sinceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a since ID everything on the timeline is old news, so only the
+	// newest post is returned, unscored, to seed the caller's cursor
+	if sinceID == "" {
+		if len(posts) == 0 {
+			return nil, nil
+		}
+		return []Curation{{Post: posts[0], Action: SKIP}}, nil
+	}
+
+	curations := make([]Curation, 0, len(posts))
+	picked := 0
+	for i := len(posts) - 1; i >= 0 && picked < limit; i-- {
+		curation, err := service.Evaluate(posts[i])
+		if err != nil {
+			return nil, err
+		}
+		curations = append(curations, *curation)
+		if curation.Action != SKIP {
+			picked++
+		}
+	}
+	return curations, nil
+}
+
+// Evaluate measures how close a post is to the knowledge base and picks what to do with it
+func (service *Curate) Evaluate(post xdotcom.Post) (*Curation, error) {
+	curation := &Curation{Post: post, Action: SKIP}
+	if strings.TrimSpace(post.Text) == "" {
+		return curation, nil
+	}
+
+	postEmbedding, err := service.llm.Embed(post.Text)
+	if err != nil {
+		return nil, err
+	}
+	passages, err := service.knowledge.NearestPassages(postEmbedding, curationPassageLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(passages) == 0 {
+		return curation, nil
+	}
+	curation.Score = passages[0].Similarity
+
+	switch {
+	case curation.Score >= service.config.QuoteThreshold:
+		voice, err := service.personas.GetPersonaForCategory("")
+		if err != nil {
+			fmt.Println(err)
+			voice = persona.Fallback()
+		}
+		commentary, err := service.llm.Prompt(service.QuoteTweetPrompt(post, passages, voice))
+		if err != nil {
+			return nil, err
+		}
+		commentary, err = voice.Enforce(strings.Trim(strings.TrimSpace(commentary), "\""))
+		if err != nil || commentary == "" || len([]rune(commentary)) > MaxTweetLength {
+			// Commentary we can't use still leaves a relevant post worth amplifying
+			curation.Action = RETWEET
+			return curation, nil
+		}
+		return service.review(curation, commentary)
+	case curation.Score >= service.config.RetweetThreshold:
+		curation.Action = RETWEET
+	}
+	return curation, nil
+}
+
+// review checks quote commentary against the content policy. Commentary it
+// blocks is stored with its violations and the post is skipped, so nothing
+// unreviewed is quoted and the cursor still moves past it
+func (service *Curate) review(curation *Curation, commentary string) (*Curation, error) {
+	commentary, report, err := service.policy.ReviewText(commentary)
+	if err != nil {
+		return nil, err
+	}
+	if report.Verdict != policy.Block {
+		curation.Action = QUOTE
+		curation.Commentary = commentary
+		return curation, nil
+	}
+
+	for _, violation := range report.Violations {
+		fmt.Printf("policy %s on commentary for %s: %s\n", violation.Verdict, curation.Post.ID, violation.Reason)
+	}
+	blocked := &policy.Blocked{
+		Draft: post.Draft{
+			Topic:  curation.Post.Text,
+			Format: QUOTE,
+			Tweets: []xdotcom.Tweet{{Text: commentary, QuoteTweetID: curation.Post.ID}},
+		},
+		Violations: report.Violations,
+	}
+	if err = service.blocked.AddBlocked(blocked); err != nil {
+		return nil, err
+	}
+	curation.Action = SKIP
+	return curation, nil
+}
+
+func (service *Curate) Publish(curation Curation) error {
+	switch curation.Action {
+	case QUOTE:
+		_, err := service.xdotcom.Tweet(xdotcom.Tweet{
+			Text:         curation.Commentary,
+			QuoteTweetID: curation.Post.ID,
+		})
+		return err
+	case RETWEET:
+		return service.xdotcom.Retweet(curation.Post.ID)
+	case SKIP:
+		return nil
+	default:
+		return fmt.Errorf("unknown curation action %q", curation.Action)
+	}
+}
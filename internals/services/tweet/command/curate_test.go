@@ -0,0 +1,148 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	xdotcom2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// relevanceLLM embeds a post as its configured relevance so the knowledge stub can echo it back as similarity
+type relevanceLLM struct {
+	relevance map[string]float32
+	reply     string
+}
+
+func (l *relevanceLLM) Prompt(prompt string) (string, error) {
+	return l.reply, nil
+}
+
+func (l *relevanceLLM) Embed(prompt string) ([]float32, error) {
+	return []float32{l.relevance[prompt]}, nil
+}
+
+type echoKnowledge struct{}
+
+func (k *echoKnowledge) AddPassage(params *knowledge.AddPassageParams, embedding []float32) error {
+	return nil
+}
+
+func (k *echoKnowledge) NearestPassages(embedding []float32, limit int) ([]knowledge.ScoredPassage, error) {
+	return []knowledge.ScoredPassage{{
+		Passage:    knowledge.Passage{Content: "Polkadot runs parachains secured by the relay chain"},
+		Similarity: float64(embedding[0]),
+	}}, nil
+}
+
+// defaultPersona returns the same persona for every category
+type defaultPersona struct {
+	persona.Persona
+}
+
+func (p *defaultPersona) AddPersona(persona *persona.Persona) error { return nil }
+func (p *defaultPersona) GetPersona(id int) (*persona.Persona, error) {
+	return &p.Persona, nil
+}
+func (p *defaultPersona) GetPersonas() ([]persona.Persona, error) {
+	return []persona.Persona{p.Persona}, nil
+}
+func (p *defaultPersona) GetPersonaForCategory(category string) (*persona.Persona, error) {
+	return &p.Persona, nil
+}
+func (p *defaultPersona) UpdatePersona(persona *persona.Persona) error { return nil }
+func (p *defaultPersona) DeletePersona(id int) error                   { return nil }
+
+type blockedStore struct {
+	blocked []policy.Blocked
+}
+
+func (b *blockedStore) AddBlocked(blocked *policy.Blocked) error {
+	b.blocked = append(b.blocked, *blocked)
+	return nil
+}
+
+func (b *blockedStore) GetBlocked(limit int) ([]policy.Blocked, error) {
+	return b.blocked, nil
+}
+
+func TestCurate_CandidatesAndPublish(t *testing.T) {
+	x := xdotcom2.NewMemoryRepository()
+	llm := &relevanceLLM{
+		relevance: map[string]float32{
+			"Async backing is live on the relay chain": 0.9,
+			"New parachain slots open this week":       0.7,
+			"Happy Friday everyone":                    0.1,
+		},
+		reply: "\"Faster blocks for every parachain, this one matters 🚀 #Polkadot\"",
+	}
+	service := NewCurate(llm, &echoKnowledge{}, x, &defaultPersona{persona.Persona{Name: "degen"}}, policy.NewEngine(), &blockedStore{}, &configs.Curation{
+		QuoteThreshold:   0.8,
+		RetweetThreshold: 0.6,
+		MaxPerRun:        2,
+	})
+
+	seedID := x.AddPost("Polkadot", "gm")
+	curations, err := service.Candidates("Polkadot", "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []Curation{{Post: curations[0].Post, Action: SKIP}}, curations)
+	assert.Equal(t, seedID, curations[0].Post.ID)
+
+	quoteID := x.AddPost("Polkadot", "Async backing is live on the relay chain")
+	retweetID := x.AddPost("Polkadot", "New parachain slots open this week")
+	x.AddPost("Polkadot", "Happy Friday everyone")
+	x.AddPost("Polkadot", "Async backing is live on the relay chain")
+
+	curations, err = service.Candidates("Polkadot", seedID, 1)
+	assert.NoError(t, err)
+	assert.Len(t, curations, 1, "posts past the cap are not evaluated")
+
+	curations, err = service.Candidates("Polkadot", seedID, 2)
+	assert.NoError(t, err)
+	assert.Len(t, curations, 2)
+	assert.Equal(t, QUOTE, curations[0].Action)
+	assert.Equal(t, "Faster blocks for every parachain, this one matters", curations[0].Commentary)
+	assert.Equal(t, RETWEET, curations[1].Action)
+
+	for _, curation := range curations {
+		assert.NoError(t, service.Publish(curation))
+	}
+	assert.Equal(t, []string{retweetID}, x.Retweets)
+	assert.Len(t, x.Tweets, 1)
+	for _, tweet := range x.Tweets {
+		assert.Equal(t, quoteID, tweet.QuoteTweetID)
+	}
+
+	// The next run picks up from the cursor, skipped posts included
+	curations, err = service.Candidates("Polkadot", retweetID, 2)
+	assert.NoError(t, err)
+	assert.Len(t, curations, 2)
+	assert.Equal(t, SKIP, curations[0].Action)
+	assert.Equal(t, QUOTE, curations[1].Action)
+}
+
+func TestCurate_BlockedCommentary(t *testing.T) {
+	x := xdotcom2.NewMemoryRepository()
+	llm := &relevanceLLM{
+		relevance: map[string]float32{"Async backing is live on the relay chain": 0.9},
+		reply:     "Async backing is live, a guaranteed 10x from here",
+	}
+	blocked := &blockedStore{}
+	engine := policy.NewEngine(policy.NewKeywordRule("keywords", []string{"guaranteed"}))
+	service := NewCurate(llm, &echoKnowledge{}, x, &defaultPersona{}, engine, blocked, &configs.Curation{
+		QuoteThreshold:   0.8,
+		RetweetThreshold: 0.6,
+	})
+
+	curation, err := service.Evaluate(xdotcom.Post{ID: "42", Text: "Async backing is live on the relay chain"})
+	assert.NoError(t, err)
+	assert.Equal(t, SKIP, curation.Action)
+	assert.Empty(t, curation.Commentary)
+	assert.Len(t, blocked.blocked, 1)
+	assert.Equal(t, "42", blocked.blocked[0].Draft.Tweets[0].QuoteTweetID)
+	assert.Empty(t, x.Tweets)
+}
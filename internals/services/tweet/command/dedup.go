@@ -0,0 +1,121 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+)
+
+// DistinctTweet drafts a post that doesn't repeat a published one. Two
+// topics can still come out as the same tweet, so a draft too close to
+// published posts is regenerated with them as examples to differ from
+func (service *Tweet) DistinctTweet(topic, context, category, format string) (*post.Draft, error) {
+	if !service.dedup.Enabled {
+		return service.VerifiedTweet(topic, context, category, format, nil)
+	}
+
+	var avoid []string
+	attempts := max(service.dedup.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		draft, err := service.VerifiedTweet(topic, context, category, format, avoid)
+		if err != nil {
+			return nil, err
+		}
+		collisions, err := service.Collisions(draft)
+		if err != nil {
+			return nil, err
+		}
+		if len(collisions) == 0 {
+			return draft, nil
+		}
+
+		fmt.Printf("dedup attempt %d: draft repeats %d published posts\n", attempt, len(collisions))
+		if attempt >= attempts {
+			return nil, errors.New("draft repeats a published post")
+		}
+		for _, collision := range collisions {
+			if !slices.Contains(avoid, collision) {
+				avoid = append(avoid, collision)
+			}
+		}
+	}
+}
+
+// Collisions returns the published posts a draft repeats. The latest posts
+// are compared word for word, which is cheap, and every post in meaning
+func (service *Tweet) Collisions(draft *post.Draft) ([]string, error) {
+	text := postText(draft.Tweets)
+
+	recent, err := service.embeddings.Recent(embedding.ContentTweet, service.dedup.RecentPosts)
+	if err != nil {
+		return nil, err
+	}
+	var collisions []string
+	shingles := Shingles(text, service.dedup.ShingleSize)
+	for _, published := range recent {
+		if Jaccard(shingles, Shingles(published.Value, service.dedup.ShingleSize)) >= service.dedup.LexicalThreshold {
+			collisions = append(collisions, published.Value)
+		}
+	}
+
+	vector, err := service.llm.Embed(text)
+	if err != nil {
+		return nil, err
+	}
+	match, err := service.embeddings.Nearest(vector, embedding.ContentTweet)
+	if err != nil {
+		return nil, err
+	}
+	if match != nil && match.Similar && !slices.Contains(collisions, match.Value) {
+		collisions = append(collisions, match.Value)
+	}
+	return collisions, nil
+}
+
+// Shingles returns the runs of size words in a text, ignoring case and
+// punctuation. A text shorter than size is a single shingle
+func Shingles(text string, size int) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	shingles := make(map[string]struct{})
+	if len(words) == 0 {
+		return shingles
+	}
+
+	size = min(max(size, 1), len(words))
+	for i := 0; i+size <= len(words); i++ {
+		shingles[strings.Join(words[i:i+size], " ")] = struct{}{}
+	}
+	return shingles
+}
+
+// Jaccard is the share of shingles two texts have in common
+func Jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// postText is the text of a post as it is stored and compared, with the
+// tweets of a thread in order
+func postText(tweets []xdotcom.Tweet) string {
+	texts := make([]string, len(tweets))
+	for i, tweet := range tweets {
+		texts[i] = tweet.Text
+	}
+	return strings.Join(texts, "\n\n")
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// publishedStore holds published posts and the nearest match it reports for any draft
+type publishedStore struct {
+	embedding.Repository
+	recent  []embedding.Match
+	nearest *embedding.Match
+}
+
+func (s *publishedStore) Recent(contentType string, limit int) ([]embedding.Match, error) {
+	return s.recent[:min(limit, len(s.recent))], nil
+}
+
+func (s *publishedStore) Nearest(vector []float32, contentType string) (*embedding.Match, error) {
+	return s.nearest, nil
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "same words, different case and punctuation", a: "Polkadot's shared security means...", b: "polkadot s SHARED security means", want: 1},
+		{name: "one word changed", a: "shared security means every parachain is safe", b: "shared security means every rollup is safe", want: 2.0 / 8},
+		{name: "nothing in common", a: "XCM fees are paid in DOT", b: "governance runs on OpenGov", want: 0},
+		{name: "shorter than a shingle", a: "gm", b: "gm", want: 1},
+		{name: "empty", a: "", b: "gm", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Jaccard(Shingles(tt.a, 3), Shingles(tt.b, 3)), 0.001)
+		})
+	}
+}
+
+func TestTweet_Collisions(t *testing.T) {
+	repeated := "Polkadot's shared security means every parachain is protected by the full validator set from day one."
+	similar := "Every parachain gets the whole validator set's protection from its first block."
+	store := &publishedStore{recent: []embedding.Match{
+		{Value: "Governance on Polkadot runs on OpenGov, where anyone can submit a referendum."},
+		{Value: repeated},
+	}}
+	service := &Tweet{llm: &queueLLM{}, embeddings: store, dedup: &configs.Dedup{RecentPosts: 10, ShingleSize: 3, LexicalThreshold: 0.5}}
+
+	draft := &post.Draft{Tweets: []xdotcom.Tweet{{Text: "Polkadot's shared security means every parachain is protected by the full validator set from day one!"}}}
+	collisions, err := service.Collisions(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{repeated}, collisions)
+
+	store.nearest = &embedding.Match{Value: similar, Similar: true}
+	draft = &post.Draft{Tweets: []xdotcom.Tweet{{Text: "From block one, a parachain is guarded by all of Polkadot's validators."}}}
+	collisions, err = service.Collisions(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{similar}, collisions)
+
+	store.nearest.Similar = false
+	collisions, err = service.Collisions(draft)
+	assert.NoError(t, err)
+	assert.Empty(t, collisions)
+}
+
+func TestDifferPrompt(t *testing.T) {
+	prompt := DifferPrompt([]string{"first post", "second post"})
+	assert.Contains(t, prompt, "[PUBLISHED:\nfirst post]")
+	assert.Contains(t, prompt, "[PUBLISHED:\nsecond post]")
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+)
+
+type verdictResponse struct {
+	Label    string `json:"label"`
+	Evidence string `json:"evidence"`
+}
+
+// FactCheck extracts the factual claims of a draft, labels each one against
+// the nearest knowledge base passages and attaches them to the draft.
+// It reports whether any claim is contradicted
+func (service *Tweet) FactCheck(draft *post.Draft) (bool, error) {
+	var texts []string
+	for _, tweet := range draft.Tweets {
+		texts = append(texts, tweet.Text)
+		if tweet.Poll != nil {
+			texts = append(texts, tweet.Poll.Options...)
+		}
+	}
+
+	response, err := service.llm.Prompt(ExtractClaimsPrompt(texts))
+	if err != nil {
+		return false, err
+	}
+	statements, err := service.convertToArray(strings.TrimSpace(response))
+	if err != nil {
+		return false, fmt.Errorf("invalid claims format: %v", err)
+	}
+
+	contradicted := false
+	draft.Claims = make([]post.Claim, 0, len(statements))
+	for _, statement := range statements {
+		if statement = strings.TrimSpace(statement); statement == "" {
+			continue
+		}
+		claim, err := service.VerifyClaim(statement)
+		if err != nil {
+			return false, err
+		}
+		if claim.Label == post.ClaimContradicted {
+			contradicted = true
+		}
+		draft.Claims = append(draft.Claims, *claim)
+	}
+	return contradicted, nil
+}
+
+// VerifyClaim labels one claim as supported, unsupported or contradicted by the knowledge base
+func (service *Tweet) VerifyClaim(statement string) (*post.Claim, error) {
+	claimEmbedding, err := service.llm.Embed(statement)
+	if err != nil {
+		return nil, err
+	}
+	passages, err := service.knowledge.NearestPassages(claimEmbedding, service.factCheck.Passages)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := &post.Claim{Text: statement}
+	for _, passage := range passages {
+		claim.PassageIDs = append(claim.PassageIDs, passage.ID)
+	}
+
+	response, err := service.llm.Prompt(VerifyClaimPrompt(statement, passages))
+	if err != nil {
+		return nil, err
+	}
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimSuffix(response, "```")
+
+	var verdict verdictResponse
+	if err = json.Unmarshal([]byte(strings.TrimSpace(response)), &verdict); err != nil {
+		return nil, fmt.Errorf("invalid claim verdict format: %v", err)
+	}
+
+	claim.Label = strings.ToLower(strings.TrimSpace(verdict.Label))
+	switch claim.Label {
+	case post.ClaimSupported, post.ClaimUnsupported, post.ClaimContradicted:
+	default:
+		return nil, fmt.Errorf("unknown claim label %q", verdict.Label)
+	}
+	claim.Evidence = strings.TrimSpace(verdict.Evidence)
+	return claim, nil
+}
+
+// claimViolations turns the contradicted claims of a draft into the
+// violations it is blocked with
+func claimViolations(draft *post.Draft) []policy.Violation {
+	var violations []policy.Violation
+	for _, claim := range draft.Claims {
+		if claim.Label != post.ClaimContradicted {
+			continue
+		}
+		reason := fmt.Sprintf("contradicted claim %q", claim.Text)
+		if claim.Evidence != "" {
+			reason = fmt.Sprintf("%s: %s", reason, claim.Evidence)
+		}
+		violations = append(violations, policy.Violation{
+			Rule:    "factcheck",
+			Verdict: policy.Block,
+			Reason:  reason,
+			Text:    claim.Text,
+		})
+	}
+	return violations
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// verifierLLM extracts a fixed list of claims and labels each one from a lookup
+type verifierLLM struct {
+	claims string
+	labels map[string]string
+}
+
+func (l *verifierLLM) Prompt(prompt string) (string, error) {
+	if strings.HasPrefix(prompt, "You fact-check posts") {
+		return l.claims, nil
+	}
+	for claim, label := range l.labels {
+		if strings.Contains(prompt, "[CLAIM: "+claim+"]") {
+			return label, nil
+		}
+	}
+	return "", errors.New("unexpected prompt")
+}
+
+func (l *verifierLLM) Embed(prompt string) ([]float32, error) {
+	return []float32{0.9}, nil
+}
+
+func TestTweet_FactCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		llm          *verifierLLM
+		labels       []string
+		contradicted bool
+		wantErr      bool
+	}{
+		{
+			name: "supported and unsupported claims",
+			llm: &verifierLLM{
+				claims: "```json\n[\"Polkadot launched in 2020\", \"Staking rewards are paid every era\"]\n```",
+				labels: map[string]string{
+					"Polkadot launched in 2020":          `{"label": "supported", "evidence": "Polkadot launched in May 2020"}`,
+					"Staking rewards are paid every era": `{"label": "Unsupported", "evidence": ""}`,
+				},
+			},
+			labels: []string{post.ClaimSupported, post.ClaimUnsupported},
+		},
+		{
+			name: "contradicted claim",
+			llm: &verifierLLM{
+				claims: `["DOT has 18 decimals"]`,
+				labels: map[string]string{
+					"DOT has 18 decimals": `{"label": "contradicted", "evidence": "DOT has 10 decimals"}`,
+				},
+			},
+			labels:       []string{post.ClaimContradicted},
+			contradicted: true,
+		},
+		{
+			name:   "no claims",
+			llm:    &verifierLLM{claims: `[]`},
+			labels: []string{},
+		},
+		{
+			name: "unknown label",
+			llm: &verifierLLM{
+				claims: `["XCM v4 shipped"]`,
+				labels: map[string]string{"XCM v4 shipped": `{"label": "maybe"}`},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Tweet{llm: tt.llm, knowledge: &echoKnowledge{}, factCheck: &configs.FactCheck{Enabled: true, MaxAttempts: 2, Passages: 3}}
+			draft := &post.Draft{Tweets: []xdotcom.Tweet{{Text: "Some tweet"}}}
+
+			contradicted, err := service.FactCheck(draft)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.contradicted, contradicted)
+
+			labels := make([]string, len(draft.Claims))
+			for i, claim := range draft.Claims {
+				labels[i] = claim.Label
+			}
+			assert.Equal(t, tt.labels, labels)
+
+			violations := claimViolations(draft)
+			if tt.contradicted {
+				assert.Len(t, violations, 1)
+				assert.Contains(t, violations[0].Reason, "DOT has 10 decimals")
+			} else {
+				assert.Empty(t, violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+// Planner decides up front what every slot of a day posts, so the day
+// follows the configured content mix instead of independent coin flips
+type Planner struct {
+	plans plan.Repository
+	mix   *plan.Mix
+	seed  int64
+}
+
+// NewPlanner checks the configured content mix once, so an unknown name in
+// it is reported at startup rather than on every plan
+func NewPlanner(plans plan.Repository, mix *configs.ContentMix) *Planner {
+	return &Planner{plans: plans, mix: newMix(mix), seed: mix.Seed}
+}
+
+// Mix is the configured content mix, limited to the topic types, categories
+// and formats the generator knows
+func (service *Planner) Mix() *plan.Mix {
+	return service.mix
+}
+
+// newMix limits the configured mix to what the generator knows. Without
+// configured categories every standard category gets an even share
+func newMix(mix *configs.ContentMix) *plan.Mix {
+	categories := mix.Categories
+	if len(categories) == 0 {
+		categories = make(map[string]float64, len(standardCategories))
+		for _, category := range standardCategories {
+			categories[category] = 1
+		}
+	}
+
+	return &plan.Mix{
+		TopicTypes:       known("topic type", mix.TopicTypes, []string{PRODUCT, STANDARD}),
+		Categories:       known("category", categories, standardCategories),
+		Formats:          known("format", mix.Formats, []string{SHORT, THREAD, POLL}),
+		MaxPerDay:        mix.MaxPerDay,
+		FallbackFormat:   SHORT,
+		NoRepeatCategory: mix.NoRepeatCategory,
+		StandardType:     STANDARD,
+	}
+}
+
+// Plan plans and stores the slots of a day. The seed comes from the date,
+// shifted by the configured seed, so replanning a day gives the same plan
+func (service *Planner) Plan(date string, times []time.Time) (*plan.Plan, error) {
+	p := plan.NewPlan(date, service.Mix(), times, plan.DaySeed(date)+service.seed)
+	if err := service.plans.SavePlan(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// known drops the weights of names the generator can't post
+func known(kind string, weights map[string]float64, names []string) map[string]float64 {
+	filtered := make(map[string]float64, len(weights))
+	for name, weight := range weights {
+		if !slices.Contains(names, name) {
+			fmt.Printf("ignoring unknown %s %q in the content mix\n", kind, name)
+			continue
+		}
+		filtered[name] = weight
+	}
+	return filtered
+}
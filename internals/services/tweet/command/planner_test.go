@@ -0,0 +1,22 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlanner_Mix(t *testing.T) {
+	planner := NewPlanner(nil, &configs.ContentMix{
+		TopicTypes: map[string]float64{STANDARD: 1, "meme": 1},
+		Formats:    map[string]float64{SHORT: 1, "gif": 2},
+	})
+
+	mix := planner.Mix()
+	assert.Equal(t, map[string]float64{STANDARD: 1}, mix.TopicTypes)
+	assert.Equal(t, map[string]float64{SHORT: 1}, mix.Formats)
+	assert.Len(t, mix.Categories, len(standardCategories))
+	// The mix is checked once, not again on every plan
+	assert.Same(t, mix, planner.Mix())
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+)
+
+// NewPolicyEngine builds the content policy drafts are reviewed against
+// before publishing. Cheap rules run first so fixes reach the judge
+func NewPolicyEngine(llm llm.Repository, config *configs.Policy) (*policy.Engine, error) {
+	predictions, err := policy.NewPatternRule("patterns", "looks like a price prediction", config.BlockedPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []policy.Rule{
+		predictions,
+		policy.NewKeywordRule("keywords", config.BlockedKeywords),
+		policy.NewLinkRule(config.AllowedDomains),
+		policy.NewCashtagRule(config.AllowedTickers),
+		policy.NewHashtagRule(config.MaxHashtags),
+		policy.NewMentionRule(config.MaxMentions),
+	}
+	if config.LLMJudge {
+		rules = append(rules, &judgeRule{llm: llm})
+	}
+	return policy.NewEngine(rules...), nil
+}
+
+// judgeRule asks the model to catch what patterns can't, like a competitor
+// being put down without naming a banned word
+type judgeRule struct {
+	llm llm.Repository
+}
+
+type judgeResponse struct {
+	Verdict string `json:"verdict"`
+	Reason  string `json:"reason"`
+}
+
+func (rule *judgeRule) Name() string {
+	return "judge"
+}
+
+func (rule *judgeRule) Check(text string) (policy.Result, error) {
+	response, err := rule.llm.Prompt(PolicyJudgePrompt(text))
+	if err != nil {
+		return policy.Result{}, err
+	}
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimSuffix(response, "```")
+
+	var judgement judgeResponse
+	if err = json.Unmarshal([]byte(strings.TrimSpace(response)), &judgement); err != nil {
+		return policy.Result{}, fmt.Errorf("invalid judgement format: %v", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(judgement.Verdict)) {
+	case policy.Pass:
+		return policy.Result{Verdict: policy.Pass}, nil
+	case policy.Block:
+		return policy.Result{Verdict: policy.Block, Reason: judgement.Reason}, nil
+	default:
+		return policy.Result{}, fmt.Errorf("unknown judgement %q", judgement.Verdict)
+	}
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+// A persona asks for its signature phrases to be worked in, so none of them
+// may be something the default policy blocks
+func TestNewPolicyEngine_seededPersonas(t *testing.T) {
+	engine, err := NewPolicyEngine(nil, configs.DefaultPolicy())
+	assert.NoError(t, err)
+
+	for _, p := range persona.Seeded() {
+		for _, phrase := range p.SignaturePhrases {
+			_, report, err := engine.ReviewText(phrase)
+			assert.NoError(t, err)
+			assert.Equal(t, policy.Pass, report.Verdict, "%s: %q is blocked by %v", p.Name, phrase, report.Violations)
+		}
+	}
+}
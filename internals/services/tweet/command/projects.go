@@ -0,0 +1,112 @@
+package command
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+)
+
+// rotationDays is how long after being featured a project is back to full
+// weight. Projects that were never featured always have full weight
+const rotationDays = 30
+
+// ProjectWeight is how likely a project is to be picked, growing by a day's
+// worth for every day since it was last featured
+func ProjectWeight(p *project.Project, now time.Time) float64 {
+	if p.LastFeaturedAt == nil {
+		return rotationDays + 1
+	}
+	days := now.Sub(*p.LastFeaturedAt).Hours() / 24
+	return min(max(days, 0), rotationDays) + 1
+}
+
+// PickProject picks a project at random, weighted towards the ones that
+// have gone longest without being featured
+func PickProject(projects []project.Project, now time.Time, random *rand.Rand) *project.Project {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	total := 0.0
+	for i := range projects {
+		total += ProjectWeight(&projects[i], now)
+	}
+	pick := random.Float64() * total
+	for i := range projects {
+		pick -= ProjectWeight(&projects[i], now)
+		if pick < 0 {
+			return &projects[i]
+		}
+	}
+	return &projects[len(projects)-1]
+}
+
+// FeaturedProject returns the featurable project with a name, or picks one
+// when the name is empty. It returns nil when no project can be featured
+func (service *Tweet) FeaturedProject(name string) (*project.Project, error) {
+	projects, err := service.projects.GetFeaturable()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return PickProject(projects, time.Now(), rand.New(rand.NewSource(time.Now().UnixNano()))), nil
+	}
+	for i := range projects {
+		if projects[i].Name == name {
+			return &projects[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ProjectTopics returns topics about a project from the registry, generated
+// with its description as context. The project is the one named, or a pick
+// of the featurable ones without a name. It returns nil when no project can
+// be featured
+func (service *Tweet) ProjectTopics(name string) ([]topic.Topic, error) {
+	p, err := service.FeaturedProject(name)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, nil
+	}
+
+	topicsPrompt, err := service.Render(ProductTopicTemplate, prompt.Data{Product: p.Name, Context: p.Description})
+	if err != nil {
+		return nil, err
+	}
+	response, err := service.llm.Prompt(topicsPrompt.Text)
+	if err != nil {
+		return nil, err
+	}
+	texts, err := service.convertToArray(response)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := newTopics(texts, PRODUCT, p.Name, ProductTopicTemplate)
+	for i := range topics {
+		topics[i].ProjectID = &p.ID
+	}
+	return topics, nil
+}
+
+// ProjectContext returns the description of the project a topic was
+// generated for, which the tweet is written with as context
+func (service *Tweet) ProjectContext(t *topic.Topic) string {
+	if t.ProjectID == nil {
+		return ""
+	}
+	p, err := service.projects.GetProject(*t.ProjectID)
+	if err != nil {
+		// The project may have been deleted since; the topic still stands on its own
+		fmt.Println(err)
+		return ""
+	}
+	return p.Description
+}
@@ -0,0 +1,160 @@
+package command
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	prompt2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/prompt"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+type projectRegistry struct {
+	projects []project.Project
+	featured map[int]time.Time
+}
+
+func (r *projectRegistry) AddProject(p *project.Project) error                    { return nil }
+func (r *projectRegistry) ImportProjects(projects []project.Project) (int, error) { return 0, nil }
+func (r *projectRegistry) GetProjects() ([]project.Project, error)                { return r.projects, nil }
+func (r *projectRegistry) UpdateProject(p *project.Project) error                 { return nil }
+func (r *projectRegistry) DeleteProject(id int) error                             { return nil }
+
+func (r *projectRegistry) GetProject(id int) (*project.Project, error) {
+	for i := range r.projects {
+		if r.projects[i].ID == id {
+			return &r.projects[i], nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func (r *projectRegistry) GetFeaturable() ([]project.Project, error) {
+	var featurable []project.Project
+	for _, p := range r.projects {
+		if p.Status != project.StatusSunset {
+			featurable = append(featurable, p)
+		}
+	}
+	return featurable, nil
+}
+
+func (r *projectRegistry) MarkFeatured(id int, at time.Time) error {
+	r.featured[id] = at
+	return nil
+}
+
+func TestProjectWeight(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		at := now.AddDate(0, 0, -days)
+		return &at
+	}
+
+	tests := []struct {
+		name         string
+		lastFeatured *time.Time
+		want         float64
+	}{
+		{"never featured", nil, rotationDays + 1},
+		{"featured today", daysAgo(0), 1},
+		{"featured a week ago", daysAgo(7), 8},
+		{"featured long ago", daysAgo(90), rotationDays + 1},
+		{"featured in the future", daysAgo(-2), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ProjectWeight(&project.Project{LastFeaturedAt: tt.lastFeatured}, now))
+		})
+	}
+}
+
+func TestPickProject(t *testing.T) {
+	now := time.Now()
+	justFeatured := now.Add(-time.Hour)
+	projects := []project.Project{
+		{ID: 1, Name: "Astar", LastFeaturedAt: &justFeatured},
+		{ID: 2, Name: "Moonbeam"},
+	}
+
+	assert.Nil(t, PickProject(nil, now, rand.New(rand.NewSource(1))))
+
+	random := rand.New(rand.NewSource(1))
+	picks := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		picks[PickProject(projects, now, random).ID]++
+	}
+	// Moonbeam has about 31 times the weight of Astar
+	assert.Greater(t, picks[2], 900)
+	assert.Greater(t, picks[1], 0)
+}
+
+func TestTweet_GetProductTopics(t *testing.T) {
+	registry, err := prompt2.NewRegistry(prompt2.DefaultTemplates(), &noOverrides{})
+	assert.NoError(t, err)
+	projects := &projectRegistry{
+		projects: []project.Project{
+			{ID: 7, Name: "Hydration", Status: project.StatusLive, Description: "Hydration is a DeFi parachain with an omnipool."},
+			{ID: 8, Name: "Old Chain", Status: project.StatusSunset, Description: "No longer running."},
+		},
+		featured: map[int]time.Time{},
+	}
+	llm := &queueLLM{replies: []string{`["Hydration's omnipool pools every asset together", " "]`}}
+	service := &Tweet{llm: llm, prompts: registry, projects: projects}
+
+	topics, err := service.GetProductTopics("")
+	assert.NoError(t, err)
+	if assert.Len(t, topics, 1) {
+		assert.Equal(t, "Hydration", topics[0].Category)
+		assert.Equal(t, PRODUCT, topics[0].TopicType)
+		if assert.NotNil(t, topics[0].ProjectID) {
+			assert.Equal(t, 7, *topics[0].ProjectID)
+		}
+		assert.Equal(t, "Hydration is a DeFi parachain with an omnipool.", service.ProjectContext(&topics[0]))
+	}
+	// Only the topics prompt was sent, with the description as context
+	if assert.Len(t, llm.prompts, 1) {
+		assert.True(t, strings.Contains(llm.prompts[0], "with an omnipool"))
+	}
+
+	// An empty registry falls back to products the model comes up with
+	projects.projects = nil
+	llm.replies = []string{`["Moonbeam"]`, `["Moonbeam runs Ethereum dApps on Polkadot"]`}
+	topics, err = service.GetProductTopics("")
+	assert.NoError(t, err)
+	if assert.Len(t, topics, 1) {
+		assert.Equal(t, "Moonbeam", topics[0].Category)
+		assert.Nil(t, topics[0].ProjectID)
+	}
+}
+
+func TestTweet_NextTopic_project(t *testing.T) {
+	registry, err := prompt2.NewRegistry(prompt2.DefaultTemplates(), &noOverrides{})
+	assert.NoError(t, err)
+	queue := &topicQueue{seen: map[string]bool{}}
+	for _, text := range []string{"Astar's dApp staking", "Astar's zkEVM"} {
+		queue.AddTopic(&topic.Topic{Text: text, TopicType: PRODUCT, Category: "Astar"}, nil)
+	}
+	projects := &projectRegistry{
+		projects: []project.Project{
+			{ID: 1, Name: "Astar", Status: project.StatusSunset},
+			{ID: 2, Name: "Moonbeam", Status: project.StatusLive, Description: "Moonbeam runs Ethereum dApps on Polkadot."},
+		},
+		featured: map[int]time.Time{},
+	}
+	llm := &queueLLM{replies: []string{`["Moonbeam's XCM precompiles"]`}}
+	service := &Tweet{llm: llm, topics: queue, prompts: registry, projects: projects, backlog: &configs.TopicBacklog{MinQueued: 1, Order: topic.OrderFIFO}}
+
+	// The slot's project is refilled and used ahead of another project's queued topics
+	next, err := service.NextTopic(PRODUCT, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Moonbeam's XCM precompiles", next.Text)
+	if assert.NotNil(t, next.ProjectID) {
+		assert.Equal(t, 2, *next.ProjectID)
+	}
+	assert.Len(t, queue.queued, 2)
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
+	prompt2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/prompt"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+type noOverrides struct{}
+
+func (o *noOverrides) AddOverride(template *prompt.Template) error { return nil }
+func (o *noOverrides) GetOverrides() ([]prompt.Template, error)    { return nil, nil }
+func (o *noOverrides) DeactivateOverrides(name string) error       { return nil }
+
+// topicQueue dedupes topics by their exact text instead of by embedding
+type topicQueue struct {
+	seen   map[string]bool
+	queued []topic.Topic
+}
+
+func (q *topicQueue) AddTopic(t *topic.Topic, embedding []float32) (bool, error) {
+	if q.seen[t.Text] {
+		return false, nil
+	}
+	q.seen[t.Text] = true
+	t.ID = len(q.seen)
+	q.queued = append(q.queued, *t)
+	return true, nil
+}
+
+func (q *topicQueue) NextTopic(topicType, category, order string) (*topic.Topic, error) {
+	var candidates []int
+	for i, t := range q.queued {
+		if t.TopicType == topicType && (category == "" || t.Category == category) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	if order == topic.OrderPriority {
+		sort.SliceStable(candidates, func(a, b int) bool {
+			return q.queued[candidates[a]].Priority > q.queued[candidates[b]].Priority
+		})
+	}
+	next := q.queued[candidates[0]]
+	q.queued = append(q.queued[:candidates[0]], q.queued[candidates[0]+1:]...)
+	return &next, nil
+}
+
+func (q *topicQueue) CountQueued(topicType, category string) (int, error) {
+	count := 0
+	for _, t := range q.queued {
+		if t.TopicType == topicType && (category == "" || t.Category == category) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *topicQueue) GetTopics(params *topic.GetTopicsParams) ([]topic.Topic, error) {
+	return q.queued, nil
+}
+func (q *topicQueue) UpdatePriority(id, priority int) error { return nil }
+func (q *topicQueue) DeleteTopic(id int) error              { return nil }
+
+func TestTweet_NextTopic(t *testing.T) {
+	registry, err := prompt2.NewRegistry(prompt2.DefaultTemplates(), &noOverrides{})
+	assert.NoError(t, err)
+	queue := &topicQueue{seen: map[string]bool{}}
+	llm := &queueLLM{replies: []string{
+		`["Shared security", "OpenGov tracks", "Shared security"]`,
+		`["OpenGov tracks", "Coretime"]`,
+	}}
+	service := &Tweet{llm: llm, topics: queue, prompts: registry, backlog: &configs.TopicBacklog{MinQueued: 2, Order: topic.OrderPriority}}
+
+	// An empty backlog is refilled once, without the duplicate
+	next, err := service.NextTopic(STANDARD, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Shared security", next.Text)
+	assert.Equal(t, STANDARD, next.TopicType)
+	assert.NotEmpty(t, next.Category)
+	assert.Equal(t, next.Category, next.Source)
+
+	// A backlog at the threshold is used without generating, highest priority first
+	queue.AddTopic(&topic.Topic{Text: "Agile coretime", TopicType: STANDARD, Source: "admin", Priority: 5}, nil)
+	next, err = service.NextTopic(STANDARD, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Agile coretime", next.Text)
+	assert.Len(t, llm.replies, 1)
+
+	// Running low refills again, skipping what was already queued or used
+	next, err = service.NextTopic(STANDARD, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "OpenGov tracks", next.Text)
+	assert.Empty(t, llm.replies)
+	if assert.Len(t, queue.queued, 1) {
+		assert.Equal(t, "Coretime", queue.queued[0].Text)
+	}
+}
+
+func TestTweet_NextTopic_category(t *testing.T) {
+	registry, err := prompt2.NewRegistry(prompt2.DefaultTemplates(), &noOverrides{})
+	assert.NoError(t, err)
+	queue := &topicQueue{seen: map[string]bool{}}
+	queue.AddTopic(&topic.Topic{Text: "Shared security", TopicType: STANDARD, Category: "complex_concepts"}, nil)
+	llm := &queueLLM{replies: []string{`["OpenGov tracks"]`, `[]`}}
+	service := &Tweet{llm: llm, topics: queue, prompts: registry, backlog: &configs.TopicBacklog{MinQueued: 1, Order: topic.OrderFIFO}}
+
+	// A planned category is refilled from its own template
+	next, err := service.NextTopic(STANDARD, "web3_governance_daos")
+	assert.NoError(t, err)
+	assert.Equal(t, "OpenGov tracks", next.Text)
+	assert.Equal(t, "web3_governance_daos", next.Category)
+	assert.Contains(t, llm.prompts[0], "governance")
+
+	// A category with nothing to offer falls back to any topic of the type
+	next, err = service.NextTopic(STANDARD, "web3_governance_daos")
+	assert.NoError(t, err)
+	assert.Equal(t, "Shared security", next.Text)
+}
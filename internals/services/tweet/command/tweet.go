@@ -5,104 +5,310 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
 	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
 	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
+	"github.com/Pr3c10us/boilerplate/packages/imagecard"
 	"math/rand"
-	"regexp"
 	"strings"
+	"text/template"
+	"time"
 )
 
 type Tweet struct {
-	llm       llm.Repository
-	embedding embedding.Repository
-	xdotcom   xdotcom.Repository
+	llm        llm.Repository
+	topics     topic.Repository
+	projects   project.Repository
+	xdotcom    xdotcom.Repository
+	post       post.Repository
+	embeddings embedding.Repository
+	experiment experiment.Repository
+	prompts    prompt.Repository
+	personas   persona.Repository
+	policy     *policy.Engine
+	blocked    policy.Repository
+	knowledge  knowledge.Repository
+	factCheck  *configs.FactCheck
+	dedup      *configs.Dedup
+	judge      llm.Repository
+	candidates *configs.Candidates
+	backlog    *configs.TopicBacklog
 }
 
-func NewTweet(llm llm.Repository, embedding embedding.Repository, xdotcom xdotcom.Repository) *Tweet {
-	return &Tweet{llm: llm, embedding: embedding, xdotcom: xdotcom}
+func NewTweet(llm llm.Repository, topics topic.Repository, projects project.Repository, xdotcom xdotcom.Repository, post post.Repository, embeddings embedding.Repository, experiment experiment.Repository, prompts prompt.Repository, personas persona.Repository, engine *policy.Engine, blocked policy.Repository, knowledge knowledge.Repository, factCheck *configs.FactCheck, dedup *configs.Dedup, judge llm.Repository, candidates *configs.Candidates, backlog *configs.TopicBacklog) *Tweet {
+	return &Tweet{llm: llm, topics: topics, projects: projects, xdotcom: xdotcom, post: post, embeddings: embeddings, experiment: experiment, prompts: prompts, personas: personas, policy: engine, blocked: blocked, knowledge: knowledge, factCheck: factCheck, dedup: dedup, judge: judge, candidates: candidates, backlog: backlog}
 }
 
-func (service *Tweet) Tweets() ([]string, bool, error) {
-	topicType := service.RandomTopicType()
-	var topic, context string
-	var err error
+// Tweets drafts the post of a planned slot. Without a plan the topic type
+// and format are left to chance
+func (service *Tweet) Tweets(slot *plan.Slot) (*post.Draft, bool, error) {
+	if slot == nil {
+		slot = &plan.Slot{TopicType: service.RandomTopicType()}
+	}
+	if slot.TopicType == JAM {
+		return nil, true, errors.New("no topic")
+	}
 
-	switch topicType {
-	case PRODUCT:
-		count := 0
-		for count < 5 {
-			count++
-			topic, err = service.GetProductTopics()
-			if err != nil {
-				continue
-			}
-			break
+	next, err := service.NextTopic(slot.TopicType, slot.Category)
+	if err != nil {
+		return nil, false, err
+	}
+	if next == nil {
+		return nil, true, errors.New("no topic")
+	}
+
+	draft, err := service.DistinctTweet(next.Text, service.ProjectContext(next), next.Category, slot.Format)
+	if err != nil {
+		return nil, false, err
+	}
+	draft.TopicType = slot.TopicType
+	draft.ProjectID = next.ProjectID
+
+	if err = service.Review(draft); err != nil {
+		return nil, true, err
+	}
+	service.attachCard(draft)
+	return draft, false, nil
+}
+
+// attachCard heads a thread with an image card. It is only rendered for the
+// draft that passed review, never for the ones regenerated on the way. A
+// card is nice to have, so the thread still goes out without one
+func (service *Tweet) attachCard(draft *post.Draft) {
+	if draft.Format != THREAD || len(draft.Tweets) == 0 {
+		return
+	}
+
+	texts := make([]string, 0, len(draft.Tweets))
+	for _, tweet := range draft.Tweets {
+		texts = append(texts, tweet.Text)
+	}
+	card, err := service.ImageCard(draft.Topic, texts)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	draft.Tweets[0].Media = []xdotcom.Media{*card}
+}
+
+// NextTopic takes the next topic of a type off the backlog, refilling the
+// backlog first when it is running low. The category is a preference: when
+// nothing of it can be queued, any topic of the type will do. Product topics
+// are tagged with their project, so the project is picked for every slot
+// rather than featuring whichever one was refilled last until its topics run out
+func (service *Tweet) NextTopic(topicType, category string) (*topic.Topic, error) {
+	if topicType == PRODUCT && category == "" {
+		featured, err := service.FeaturedProject("")
+		if err != nil {
+			return nil, err
 		}
-		if count >= 5 {
-			return nil, true, errors.New("error getting appropriate response from model")
+		if featured != nil {
+			category = featured.Name
 		}
+	}
 
-	case JAM:
-	default:
-		count := 0
-		for count < 5 {
-			count++
-			topic, err = service.GetStandardTopics()
-			if err != nil {
-				continue
-			}
-			break
-		}
-		if count >= 5 {
-			return nil, true, errors.New("error getting appropriate response from model")
+	queued, err := service.topics.CountQueued(topicType, category)
+	if err != nil {
+		return nil, err
+	}
+	if queued < service.backlog.MinQueued {
+		added, err := service.RefillTopics(topicType, category)
+		if err != nil {
+			// Whatever is still queued can be used
+			fmt.Println(err)
 		}
+		fmt.Printf("added %d %s topics to the backlog\n", added, topicType)
 	}
 
-	if topic == "" {
-		return nil, true, errors.New("no topic")
+	next, err := service.topics.NextTopic(topicType, category, service.backlog.Order)
+	if err != nil || next != nil || category == "" {
+		return next, err
+	}
+	return service.topics.NextTopic(topicType, "", service.backlog.Order)
+}
+
+// RefillTopics generates a batch of topics of a type and queues the ones
+// that are not similar to anything queued or tweeted before. Topics are
+// generated for the category, or a random one without it
+func (service *Tweet) RefillTopics(topicType, category string) (int, error) {
+	var topics []topic.Topic
+	var err error
+	for attempt := 0; attempt < 5; attempt++ {
+		if topicType == PRODUCT {
+			topics, err = service.GetProductTopics(category)
+		} else {
+			topics, err = service.GetStandardTopics(category)
+		}
+		if err == nil {
+			break
+		}
 	}
-	topicTweeted, embeddingStr, err := service.TopicAlreadyTweeted(topic)
 	if err != nil {
-		return nil, false, err
+		return 0, fmt.Errorf("error getting appropriate response from model: %v", err)
 	}
-	if *topicTweeted {
-		return nil, true, errors.New("topic tweeted")
+
+	added := 0
+	for i := range topics {
+		topicEmbedding, err := service.llm.Embed(topics[i].Text)
+		if err != nil {
+			return added, err
+		}
+		ok, err := service.topics.AddTopic(&topics[i], topicEmbedding)
+		if err != nil {
+			return added, err
+		}
+		if ok {
+			added++
+		}
 	}
+	return added, nil
+}
 
-	err = service.embedding.AddEmbedding(embeddingStr, topic)
-	if err != nil {
-		return nil, false, err
+// VerifiedTweet generates a draft and fact-checks it against the knowledge
+// base, regenerating it while it contradicts the knowledge base. A draft
+// that still does after the last attempt is stored as blocked
+func (service *Tweet) VerifiedTweet(topic, context, category, format string, avoid []string) (*post.Draft, error) {
+	if !service.factCheck.Enabled {
+		return service.GetTweet(topic, context, category, format, avoid)
 	}
 
-	tweets, err := service.GetTweet(topic, context)
+	attempts := max(service.factCheck.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		draft, err := service.GetTweet(topic, context, category, format, avoid)
+		if err != nil {
+			return nil, err
+		}
+		contradicted, err := service.FactCheck(draft)
+		if err != nil {
+			return nil, err
+		}
+		if !contradicted {
+			return draft, nil
+		}
+
+		violations := claimViolations(draft)
+		for _, violation := range violations {
+			fmt.Printf("fact-check attempt %d: %s\n", attempt, violation.Reason)
+		}
+		if attempt >= attempts {
+			if err = service.blocked.AddBlocked(&policy.Blocked{Draft: *draft, Violations: violations}); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("draft contradicts the knowledge base")
+		}
+	}
+}
+
+// Review checks a draft against the content policy, applying its fixes.
+// A blocked draft is stored with its violations instead of being returned
+func (service *Tweet) Review(draft *post.Draft) error {
+	report, err := service.policy.Review(draft)
 	if err != nil {
-		return nil, false, err
+		return err
+	}
+	for _, violation := range report.Violations {
+		fmt.Printf("policy %s on tweet %d: %s\n", violation.Verdict, violation.Tweet+1, violation.Reason)
+	}
+	if report.Verdict != policy.Block {
+		return nil
 	}
 
-	return tweets, false, nil
+	if err = service.blocked.AddBlocked(&policy.Blocked{Draft: *draft, Violations: report.Violations}); err != nil {
+		return err
+	}
+	return errors.New("draft blocked by content policy")
 }
 
-func (service *Tweet) SendTweet(tweets []string) error {
+// SendTweet publishes a draft as a chain of tweets and records it so its
+// engagement can be collected later
+func (service *Tweet) SendTweet(draft *post.Draft) error {
 	prevTweetID := ""
-	for _, tweet := range tweets {
-		id, err := service.xdotcom.Tweet(xdotcom.Tweet{
-			Text:            tweet,
-			PreviousTweetID: prevTweetID,
-		})
+	tweetIDs := make([]string, 0, len(draft.Tweets))
+	for _, tweet := range draft.Tweets {
+		tweet.PreviousTweetID = prevTweetID
+		id, err := service.xdotcom.Tweet(tweet)
 		if err != nil {
 			return err
 		}
 		prevTweetID = id
+		tweetIDs = append(tweetIDs, id)
+	}
+
+	published := &post.Post{
+		Topic:          draft.Topic,
+		TopicType:      draft.TopicType,
+		Category:       draft.Category,
+		Format:         draft.Format,
+		PromptTemplate: draft.PromptTemplate,
+		PromptVersion:  draft.PromptVersion,
+		ExperimentID:   draft.ExperimentID,
+		VariantID:      draft.VariantID,
+		PersonaID:      draft.PersonaID,
+		CandidateBatch: draft.CandidateBatch,
+		TweetIDs:       tweetIDs,
+		Claims:         draft.Claims,
+		PostedAt:       time.Now(),
+	}
+	if err := service.post.AddPost(published); err != nil {
+		return err
+	}
+	service.remember(published.ID, draft.Tweets)
+
+	// The tweet is already out, so a project that can't be marked only
+	// comes round again sooner than it should
+	if draft.ProjectID != nil {
+		if err := service.projects.MarkFeatured(*draft.ProjectID, time.Now()); err != nil {
+			fmt.Println(err)
+		}
 	}
 	return nil
 }
 
+// remember embeds the text of a published post, so it can be searched for
+// with the post it went out in. The tweet is already out, so a failure only
+// leaves it out of searches
+func (service *Tweet) remember(postID int, tweets []xdotcom.Tweet) {
+	text := postText(tweets)
+	vector, err := service.llm.Embed(text)
+	if err == nil {
+		_, err = service.embeddings.AddEmbedding(vector, text, embedding.ContentTweet, &postID)
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
 const (
 	PRODUCT  = "product"
 	STANDARD = "standard"
 	JAM      = "jam"
 )
 
+const (
+	SHORT  = "short"
+	THREAD = "thread"
+	POLL   = "poll"
+)
+
+const (
+	MinPollOptions      = 2
+	MaxPollOptions      = 4
+	MaxPollOptionLength = 25
+	MaxTweetLength      = 280
+	TweetCharacters     = 250
+	MaxThreadTweets     = 3
+	PollDurationMinutes = 24 * 60
+)
+
 func (service *Tweet) RandomTopicType() string {
 	list := []string{
 		PRODUCT, STANDARD, JAM,
@@ -147,85 +353,173 @@ func (service *Tweet) convertToArray(input string) ([]string, error) {
 	return result, nil
 }
 
-func (service *Tweet) GetProductTopics() (string, error) {
-	response, err := service.llm.Prompt(service.ProductListPrompt())
+// GetProductTopics returns topics about a product, tagged with the product
+// as their category. Products come from the project registry, and are only
+// made up by the model while the registry has nothing to feature. The
+// product is the registered project named, or a pick without a name
+func (service *Tweet) GetProductTopics(name string) ([]topic.Topic, error) {
+	topics, err := service.ProjectTopics(name)
+	if err != nil || topics != nil {
+		return topics, err
+	}
+
+	productList, err := service.Render(ProductListTemplate, prompt.Data{})
+	if err != nil {
+		return nil, err
+	}
+	response, err := service.llm.Prompt(productList.Text)
 	if err != nil {
 		println(err)
-		return "", err
+		return nil, err
 	}
 
 	products, err := service.convertToArray(response)
 	if err != nil {
 		println(err)
-		return "", err
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, errors.New("no products")
 	}
 
-	topicsPrompt := service.ProductTopicPrompt(products[rand.Intn(len(products)-0)])
-	topicResponse, err := service.llm.Prompt(topicsPrompt)
+	product := products[rand.Intn(len(products)-0)]
+	topicsPrompt, err := service.Render(ProductTopicTemplate, prompt.Data{Product: product})
+	if err != nil {
+		return nil, err
+	}
+	topicResponse, err := service.llm.Prompt(topicsPrompt.Text)
 	if err != nil {
 		println(err)
-		return "", err
+		return nil, err
 	}
 
-	topics, err := service.convertToArray(topicResponse)
+	texts, err := service.convertToArray(topicResponse)
 	if err != nil {
 		println(err)
-		return "", err
+		return nil, err
 	}
 
-	return topics[rand.Intn(len(topics)-0)], nil
+	return newTopics(texts, PRODUCT, product, ProductTopicTemplate), nil
 }
 
-func (service *Tweet) GetStandardTopics() (string, error) {
-	topicsPrompt := service.RandomStandardPrompt()
-	topicResponse, err := service.llm.Prompt(topicsPrompt)
+// GetStandardTopics returns topics of a category, or of a random one when it
+// is empty, tagged with the category prompt they came from
+func (service *Tweet) GetStandardTopics(category string) ([]topic.Topic, error) {
+	if category == "" {
+		category = service.RandomStandardCategory()
+	}
+	topicsPrompt, err := service.Render(category, prompt.Data{})
+	if err != nil {
+		return nil, err
+	}
+	topicResponse, err := service.llm.Prompt(topicsPrompt.Text)
 	if err != nil {
 		println(err)
-		return "", err
+		return nil, err
 	}
 
-	topics, err := service.convertToArray(topicResponse)
+	texts, err := service.convertToArray(topicResponse)
 	if err != nil {
 		println(err)
-		return "", err
+		return nil, err
 	}
 
-	return topics[rand.Intn(len(topics)-0)], nil
+	return newTopics(texts, STANDARD, category, category), nil
 }
 
-func (service *Tweet) TopicAlreadyTweeted(topic string) (*bool, []float32, error) {
-	tweetEmbedding, err := service.llm.Embed(topic)
-	if err != nil {
-		return nil, nil, err
+func newTopics(texts []string, topicType, category, source string) []topic.Topic {
+	topics := make([]topic.Topic, 0, len(texts))
+	for _, text := range texts {
+		if text = strings.TrimSpace(text); text != "" {
+			topics = append(topics, topic.Topic{Text: text, TopicType: topicType, Category: category, Source: source})
+		}
 	}
-	used, err := service.embedding.SimilarValuesExist(tweetEmbedding)
+	return topics
+}
+
+// Persona returns the voice to write a category in. Without a stored one
+// the content is written in the built-in default voice, as the prompts
+// carry no voice of their own
+func (service *Tweet) Persona(category string) *persona.Persona {
+	p, err := service.personas.GetPersonaForCategory(category)
 	if err != nil {
-		return nil, nil, err
+		fmt.Println(err)
+		return persona.Fallback()
 	}
-
-	return used, tweetEmbedding, nil
+	return p
 }
 
-func (service *Tweet) GetTweet(topic, context string) ([]string, error) {
-	tweetTypes := []string{"short", "thread"}
-	tweetType := tweetTypes[rand.Intn(len(tweetTypes)-0)]
-	//tweetType := tweetTypes[0]
+// GetTweet drafts a tweet about a topic in a format, or in a random format when it is empty
+func (service *Tweet) GetTweet(topic, context, category, format string, avoid []string) (*post.Draft, error) {
+	tweetType := format
+	if tweetType == "" {
+		tweetTypes := []string{SHORT, THREAD, POLL}
+		tweetType = tweetTypes[rand.Intn(len(tweetTypes)-0)]
+	}
+
+	voice := service.Persona(category)
+	draft := &post.Draft{Topic: topic, Category: category, Format: tweetType}
+	if voice.ID != 0 {
+		draft.PersonaID = &voice.ID
+	}
+	switch tweetType {
+	case SHORT:
+		draft.PromptTemplate = ShortTweetTemplate
+	case POLL:
+		draft.PromptTemplate = PollTemplate
+	default:
+		draft.PromptTemplate = ThreadTemplate
+	}
+
+	data := prompt.Data{Topic: topic, Context: context, Persona: voice.Instructions()}
+	rendered, err := service.Render(draft.PromptTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	prompt := rendered.Text
+	draft.PromptVersion = rendered.Version
+
+	// An experiment on the template swaps in one of its variants; the
+	// template is kept if the variant cannot be used
+	variantPrompt, e, variant, err := service.ExperimentPrompt(draft.PromptTemplate, data)
+	if err != nil {
+		fmt.Println(err)
+	} else if variant != nil {
+		prompt = variantPrompt
+		draft.ExperimentID = &e.ID
+		draft.VariantID = &variant.ID
+	}
+	if len(avoid) > 0 {
+		prompt += DifferPrompt(avoid)
+	}
 
-	var prompt string
-	if tweetType == "short" {
-		prompt = service.ShortTweetPrompt(topic, context)
-	} else {
-		prompt = service.TweetThreadPrompt(topic, context)
+	best, err := service.BestCandidate(draft, prompt, voice)
+	if err != nil {
+		return nil, err
 	}
+	draft.Tweets = best.Tweets
+	return draft, nil
+}
 
+// ParseResponse turns the model's response into the tweets of a format,
+// written in the persona's voice
+func (service *Tweet) ParseResponse(tweetType, response string, voice *persona.Persona) ([]xdotcom.Tweet, error) {
 	switch tweetType {
-	case "short":
-		response, err := service.llm.Prompt(prompt)
+	case POLL:
+		poll, err := service.ParsePoll(response)
 		if err != nil {
-			fmt.Println(err)
 			return nil, err
 		}
-		response = service.RemoveEmojis(response)
+		if poll.Text, err = voice.Enforce(poll.Text); err != nil {
+			return nil, err
+		}
+		for i, option := range poll.Poll.Options {
+			if poll.Poll.Options[i], err = voice.Enforce(option); err != nil {
+				return nil, err
+			}
+		}
+		return []xdotcom.Tweet{*poll}, nil
+	case SHORT:
 		if strings.HasPrefix(response, "\"") && strings.HasSuffix(response, "\"") {
 			// Remove the code block markers
 			trimmedInput := strings.TrimPrefix(response, "\"")
@@ -233,32 +527,181 @@ func (service *Tweet) GetTweet(topic, context string) ([]string, error) {
 
 			// Trim any extra spaces, newlines, or tabs around the JSON content
 			trimmedInput = strings.TrimSpace(trimmedInput)
-			return []string{trimmedInput}, nil
+			response = trimmedInput
 		}
-		return []string{response}, nil
-	default:
-		response, err := service.llm.Prompt(prompt)
+		response, err := voice.Enforce(response)
 		if err != nil {
-			fmt.Println(err)
 			return nil, err
 		}
-		response = service.RemoveEmojis(response)
-		tweets, err := service.convertToArray(response)
+		return []xdotcom.Tweet{{Text: response}}, nil
+	default:
+		texts, err := service.convertToArray(response)
 		if err != nil {
-			fmt.Println(err)
 			return nil, err
 		}
 
+		tweets := make([]xdotcom.Tweet, len(texts))
+		for i, text := range texts {
+			if tweets[i].Text, err = voice.Enforce(text); err != nil {
+				return nil, err
+			}
+		}
 		return tweets, nil
 	}
 }
-func (service *Tweet) RemoveEmojis(text string) string {
-	// Regular expression pattern to match emojis
-	emojiPattern := `[\x{1F600}-\x{1F64F}\x{1F300}-\x{1F5FF}\x{1F680}-\x{1F6FF}\x{1F700}-\x{1F77F}\x{1F780}-\x{1F7FF}\x{1F800}-\x{1F8FF}\x{1F900}-\x{1F9FF}\x{1FA00}-\x{1FA6F}\x{1FA70}-\x{1FAFF}\x{2600}-\x{26FF}\x{2700}-\x{27BF}\x{2300}-\x{23FF}\x{2B50}\x{2B06}\x{1F004}-\x{1F0CF}]`
 
-	// Compile the regular expression
-	re := regexp.MustCompile(emojiPattern)
+// ExperimentPrompt renders the variant an active experiment assigns to this
+// item, or returns a nil variant when the template has no experiment
+func (service *Tweet) ExperimentPrompt(templateName string, data prompt.Data) (string, *experiment.Experiment, *experiment.Variant, error) {
+	data.Limits = PromptLimits
+	e, err := service.experiment.GetActiveExperiment(templateName)
+	if err != nil || e == nil {
+		return "", nil, nil, err
+	}
+
+	variant := pickVariant(e)
+	if variant == nil {
+		return "", nil, nil, nil
+	}
+
+	tmpl, err := template.New(variant.Name).Option("missingkey=error").Parse(variant.Body)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("invalid variant %q: %v", variant.Name, err)
+	}
+	var prompt strings.Builder
+	if err = tmpl.Execute(&prompt, data); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to render variant %q: %v", variant.Name, err)
+	}
+	return prompt.String(), e, variant, nil
+}
+
+// pickVariant returns the winner of a promoted experiment, or a variant drawn by traffic weight
+func pickVariant(e *experiment.Experiment) *experiment.Variant {
+	if e.Status == experiment.StatusPromoted && e.WinnerVariantID != nil {
+		for i := range e.Variants {
+			if e.Variants[i].ID == *e.WinnerVariantID {
+				return &e.Variants[i]
+			}
+		}
+		return nil
+	}
+
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return nil
+	}
+	pick := rand.Intn(total)
+	for i := range e.Variants {
+		if pick < e.Variants[i].Weight {
+			return &e.Variants[i]
+		}
+		pick -= e.Variants[i].Weight
+	}
+	return nil
+}
+
+type pollResponse struct {
+	Question string   `json:"question"`
+	Options  []string `json:"options"`
+}
+
+// ParsePoll reads the model's poll JSON and checks it against X's poll limits
+func (service *Tweet) ParsePoll(input string) (*xdotcom.Tweet, error) {
+	input = strings.TrimSpace(input)
+	input = strings.TrimPrefix(input, "```json")
+	input = strings.TrimSuffix(input, "```")
+
+	var poll pollResponse
+	if err := json.Unmarshal([]byte(strings.TrimSpace(input)), &poll); err != nil {
+		return nil, fmt.Errorf("invalid poll format: %v", err)
+	}
+
+	question := strings.TrimSpace(poll.Question)
+	if question == "" {
+		return nil, errors.New("poll question is empty")
+	}
+	if len([]rune(question)) > MaxTweetLength {
+		return nil, fmt.Errorf("poll question is longer than %d characters", MaxTweetLength)
+	}
+	if len(poll.Options) < MinPollOptions || len(poll.Options) > MaxPollOptions {
+		return nil, fmt.Errorf("poll must have between %d and %d options, got %d", MinPollOptions, MaxPollOptions, len(poll.Options))
+	}
+
+	seen := map[string]bool{}
+	options := make([]string, len(poll.Options))
+	for i, option := range poll.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, fmt.Errorf("poll option %d is empty", i+1)
+		}
+		if len([]rune(option)) > MaxPollOptionLength {
+			return nil, fmt.Errorf("poll option %q is longer than %d characters", option, MaxPollOptionLength)
+		}
+		if seen[strings.ToLower(option)] {
+			return nil, fmt.Errorf("poll option %q is duplicated", option)
+		}
+		seen[strings.ToLower(option)] = true
+		options[i] = option
+	}
+
+	return &xdotcom.Tweet{
+		Text: question,
+		Poll: &xdotcom.Poll{
+			Options:         options,
+			DurationMinutes: PollDurationMinutes,
+		},
+	}, nil
+}
+
+type imageCardResponse struct {
+	Headline string `json:"headline"`
+	Caption  string `json:"caption"`
+}
+
+// ImageCard asks the model for the key stat or quote of a thread and renders it as a branded PNG
+func (service *Tweet) ImageCard(topic string, thread []string) (*xdotcom.Media, error) {
+	response, err := service.llm.Prompt(service.ImageCardPrompt(topic, thread))
+	if err != nil {
+		return nil, err
+	}
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimSuffix(response, "```")
+
+	var content imageCardResponse
+	if err = json.Unmarshal([]byte(strings.TrimSpace(response)), &content); err != nil {
+		return nil, fmt.Errorf("invalid image card format: %v", err)
+	}
+	content.Headline = service.RemoveEmojis(content.Headline)
+	content.Caption = service.RemoveEmojis(content.Caption)
+
+	image, err := imagecard.Render(imagecard.Card{
+		Headline: content.Headline,
+		Caption:  content.Caption,
+		Brand:    CardBrand,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	altText := content.Headline
+	if content.Caption != "" {
+		altText = fmt.Sprintf("%s. %s", content.Headline, content.Caption)
+	}
+	return &xdotcom.Media{
+		Data:     image,
+		MimeType: "image/png",
+		AltText:  altText,
+	}, nil
+}
+func (service *Tweet) RemoveEmojis(text string) string {
+	return removeEmojis(text)
+}
 
+func removeEmojis(text string) string {
 	// Replace emojis with an empty string
-	return re.ReplaceAllString(text, "")
+	return persona.EmojiPattern.ReplaceAllString(text, "")
 }
@@ -1,18 +1,20 @@
 package command
 
 import (
-	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
 	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
 	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
+	persona2 "github.com/Pr3c10us/boilerplate/internals/infrastructures/adapters/persona"
+	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
 )
 
 func TestTweet_convertToArray(t *testing.T) {
 	type fields struct {
-		llm       llm.Repository
-		embedding embedding.Repository
-		xdotcom   xdotcom.Repository
+		llm     llm.Repository
+		topics  topic.Repository
+		xdotcom xdotcom.Repository
 	}
 	type args struct {
 		input string
@@ -27,9 +29,9 @@ func TestTweet_convertToArray(t *testing.T) {
 		{
 			name: "ss",
 			fields: fields{
-				llm:       nil,
-				embedding: nil,
-				xdotcom:   nil,
+				llm:     nil,
+				topics:  nil,
+				xdotcom: nil,
 			},
 			args:    args{input: "```json\n[\n    \"(1/7) � Ever heard of futarchy? Zeitgeist is shaking up #Polkadot with a g\novernance model that ties decisions to real-world events. Curious? It might just\n change how we think about decision-making! �� #Polkadot\",\n    \"(2/7) Imagine if governance decisions were based not just on votes, but on\ntangible outcomes. Zeitgeist's futarchy does just that, aligning incentives for\nmore effective results. What does this mean for #Polkadot?\",\n    \"(3/7) � Let's break it down: In futarchy, participants bet on the outcome\nof proposals. This betting reveals insights about potential success or failure.\nHow does this translate to better governance?\",\n    \"(4/7) Essentially, predictions come from those who will win or lose based o\nn real-world results. It's like having skin in the game, ensuring decisions serv\ne the community well. Curious about its impact on #Polkadot?\",\n    \"(5/7) � By integrating this model, #Polkadot could see more strategic and\ntransparent decision-making. It's a blend of democratic principles with market e\nfficiency. But there are challenges too. Let's explore!\",\n    \"(6/7) Critics argue risks in prediction markets, but proponents highlight i\nncreased accountability and innovation within #Polkadot. Zeitgeist is a pioneer;\n will others follow? What do you think?\",\n    \"(7/7) � Futarchy could redefine governance. Zeitgeist is leading the charg\ne on #Polkadot! Share your thoughts or ask questions below. Dive into the future\n of governance! #Innovation #Web3 #Blockchain\"\n]\n```"},
 			want:    []string{},
@@ -39,9 +41,9 @@ func TestTweet_convertToArray(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service := &Tweet{
-				llm:       tt.fields.llm,
-				embedding: tt.fields.embedding,
-				xdotcom:   tt.fields.xdotcom,
+				llm:     tt.fields.llm,
+				topics:  tt.fields.topics,
+				xdotcom: tt.fields.xdotcom,
 			}
 			got, err := service.convertToArray(tt.args.input)
 			if (err != nil) != tt.wantErr {
@@ -54,3 +56,85 @@ func TestTweet_convertToArray(t *testing.T) {
 		})
 	}
 }
+
+func TestTweet_ParsePoll(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *xdotcom.Tweet
+		wantErr bool
+	}{
+		{
+			name:  "valid poll",
+			input: "```json\n{\"question\": \"Which parachain feature would you use first?\", \"options\": [\"Cross-chain swaps\", \" On-chain identity \"]}\n```",
+			want: &xdotcom.Tweet{
+				Text: "Which parachain feature would you use first?",
+				Poll: &xdotcom.Poll{
+					Options:         []string{"Cross-chain swaps", "On-chain identity"},
+					DurationMinutes: PollDurationMinutes,
+				},
+			},
+		},
+		{
+			name:    "too few options",
+			input:   `{"question": "Staking?", "options": ["Yes"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "too many options",
+			input:   `{"question": "Staking?", "options": ["A", "B", "C", "D", "E"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "option too long",
+			input:   `{"question": "Staking?", "options": ["Nominating through a staking pool", "Solo"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate options",
+			input:   `{"question": "Staking?", "options": ["Pools", "pools"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing question",
+			input:   `{"options": ["Pools", "Solo"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			input:   "Which parachain feature would you use first?",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Tweet{}
+			got, err := service.ParsePoll(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePoll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePoll() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTweet_Persona(t *testing.T) {
+	personas := persona2.NewMemoryRepository()
+	service := &Tweet{personas: personas}
+
+	assert.Equal(t, "developer", service.Persona("developer_focused").Name)
+	assert.Equal(t, "degen", service.Persona("ecosystem").Name)
+
+	// Without a stored default the built-in voice is used, not an empty one
+	stored, _ := personas.GetPersonas()
+	for _, p := range stored {
+		_ = personas.DeletePersona(p.ID)
+	}
+	voice := service.Persona("ecosystem")
+	assert.Equal(t, "degen", voice.Name)
+	assert.Zero(t, voice.ID)
+	assert.Contains(t, voice.Instructions(), "## Tone")
+}
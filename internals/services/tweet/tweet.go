@@ -2,9 +2,19 @@ package tweet
 
 import (
 	"github.com/Pr3c10us/boilerplate/internals/domains/embedding"
+	"github.com/Pr3c10us/boilerplate/internals/domains/experiment"
+	"github.com/Pr3c10us/boilerplate/internals/domains/knowledge"
 	"github.com/Pr3c10us/boilerplate/internals/domains/llm"
+	"github.com/Pr3c10us/boilerplate/internals/domains/persona"
+	"github.com/Pr3c10us/boilerplate/internals/domains/plan"
+	"github.com/Pr3c10us/boilerplate/internals/domains/policy"
+	"github.com/Pr3c10us/boilerplate/internals/domains/post"
+	"github.com/Pr3c10us/boilerplate/internals/domains/project"
+	"github.com/Pr3c10us/boilerplate/internals/domains/prompt"
+	"github.com/Pr3c10us/boilerplate/internals/domains/topic"
 	"github.com/Pr3c10us/boilerplate/internals/domains/xdotcom"
 	"github.com/Pr3c10us/boilerplate/internals/services/tweet/command"
+	"github.com/Pr3c10us/boilerplate/packages/configs"
 )
 
 type Services struct {
@@ -13,16 +23,20 @@ type Services struct {
 }
 
 type Commands struct {
-	Tweet *command.Tweet
+	Tweet   *command.Tweet
+	Curate  *command.Curate
+	Planner *command.Planner
 }
 
 type Queries struct {
 }
 
-func NewTweetService(llm llm.Repository, embedding embedding.Repository, xdotcom xdotcom.Repository) Services {
+func NewTweetService(llm llm.Repository, judge llm.Repository, topics topic.Repository, projects project.Repository, xdotcom xdotcom.Repository, knowledge knowledge.Repository, post post.Repository, embeddings embedding.Repository, experiment experiment.Repository, prompts prompt.Repository, personas persona.Repository, engine *policy.Engine, blocked policy.Repository, plans plan.Repository, environmentVariables *configs.EnvironmentVariables) Services {
 	return Services{
 		Commands: Commands{
-			Tweet: command.NewTweet(llm, embedding, xdotcom),
+			Tweet:   command.NewTweet(llm, topics, projects, xdotcom, post, embeddings, experiment, prompts, personas, engine, blocked, knowledge, environmentVariables.FactCheck, environmentVariables.Dedup, judge, environmentVariables.Candidates, environmentVariables.TopicBacklog),
+			Curate:  command.NewCurate(llm, knowledge, xdotcom, personas, engine, blocked, environmentVariables.Curation),
+			Planner: command.NewPlanner(plans, environmentVariables.ContentMix),
 		},
 		Queries: Queries{},
 	}
@@ -3,8 +3,11 @@ package configs
 import (
 	"github.com/joho/godotenv"
 	"log"
+	"math"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -68,8 +71,164 @@ type XDotCom struct {
 	BearerToken    string
 }
 
+type Curation struct {
+	Accounts         []string
+	QuoteThreshold   float64
+	RetweetThreshold float64
+	Interval         time.Duration
+	MaxPerRun        int
+}
+
+type Mentions struct {
+	Interval            time.Duration
+	AutoReplyConfidence float64
+	DailyReplyLimit     int
+	UserCooldown        time.Duration
+}
+
+type Analytics struct {
+	Interval     time.Duration
+	LookbackDays int
+}
+
+type PostingTimes struct {
+	LookbackDays       int
+	Exploration        float64
+	ExplorationBonus   float64
+	MinTweetsPerWindow int
+	MaxTweetsPerWindow int
+}
+
+type Policy struct {
+	BlockedPatterns []string
+	BlockedKeywords []string
+	AllowedDomains  []string
+	AllowedTickers  []string
+	MaxHashtags     int
+	MaxMentions     int
+	LLMJudge        bool
+}
+
+type FactCheck struct {
+	Enabled     bool
+	MaxAttempts int
+	Passages    int
+}
+
+// Dedup is how a draft is checked against what was published. Drafts
+// sharing LexicalThreshold of their ShingleSize-word runs with one of the
+// last RecentPosts posts, or within the tweet vector search threshold of
+// any, are regenerated up to MaxAttempts times
+type Dedup struct {
+	Enabled          bool
+	MaxAttempts      int
+	RecentPosts      int
+	ShingleSize      int
+	LexicalThreshold float64
+}
+
+type Candidates struct {
+	Count       int
+	JudgeModel  string
+	JudgeWeight float64
+	Rubric      string
+}
+
+type TopicBacklog struct {
+	MinQueued int
+	Order     string
+}
+
+// Adapter modes. Memory keeps users, cache, embeddings, plans and outgoing
+// emails, texts and tweets in memory, for demos and end-to-end tests
+const (
+	AdapterModeLive   = "live"
+	AdapterModeMemory = "memory"
+)
+
+// Scheduler stores. Postgres lets small deployments run without Redis
+const (
+	SchedulerStoreRedis    = "redis"
+	SchedulerStorePostgres = "postgres"
+	SchedulerStoreMemory   = "memory"
+)
+
+// Embedding providers. Hashing embeds locally, without network access
+const (
+	EmbeddingProviderOpenAI  = "openai"
+	EmbeddingProviderHashing = "hashing"
+)
+
+// Embeddings is the provider and model values are embedded with. Dimensions
+// of zero keep the model's own. A migration re-embeds MigrationBatch values
+// at a time. The model and dimensions are only the ones to start with: once
+// a migration has cut over, its model is switched to with Use, and Active
+// is what values are embedded and compared with. The hashing provider has
+// only the one model, so it is never switched
+type Embeddings struct {
+	Provider       string
+	Model          string
+	Dimensions     int
+	MigrationBatch int
+
+	mutex sync.RWMutex
+}
+
+// Active returns the model and dimensions values are embedded with
+func (embeddings *Embeddings) Active() (string, int) {
+	embeddings.mutex.RLock()
+	defer embeddings.mutex.RUnlock()
+	return embeddings.Model, embeddings.Dimensions
+}
+
+// Use switches the model values are embedded with
+func (embeddings *Embeddings) Use(model string, dimensions int) {
+	embeddings.mutex.Lock()
+	defer embeddings.mutex.Unlock()
+	embeddings.Model, embeddings.Dimensions = model, dimensions
+}
+
+// EmbeddingCache keeps embeddings in Redis for TTL, and in Postgres for
+// good when Persist is set
+type EmbeddingCache struct {
+	Enabled bool
+	TTL     time.Duration
+	Persist bool
+}
+
+// Health bounds the readiness checks. Results are reused for CacheTTL, so
+// probes don't turn into a stream of paid API calls
+type Health struct {
+	CheckTimeout    time.Duration
+	CacheTTL        time.Duration
+	HeartbeatMaxAge time.Duration
+}
+
+// VectorSearch is how embeddings are compared. Thresholds are per content
+// type, in the metric's own score, with Threshold for the rest. A zero
+// Lookback compares against every stored embedding
+type VectorSearch struct {
+	Metric     string
+	Threshold  float64
+	Thresholds map[string]float64
+	Lookback   time.Duration
+}
+
+// ContentMix holds the target shares of a day's posts, written as
+// "name:weight" pairs. Categories left empty share the standard posts evenly
+type ContentMix struct {
+	TopicTypes       map[string]float64
+	Categories       map[string]float64
+	Formats          map[string]float64
+	MaxPerDay        map[string]int
+	NoRepeatCategory bool
+	Seed             int64
+}
+
 type EnvironmentVariables struct {
 	Port                  string
+	ShutdownTimeout       time.Duration
+	SchedulerDrainTimeout time.Duration
 	JWTSecret             string
 	JWTMaxAge             time.Duration
 	RefreshJWTSecret      string
@@ -78,6 +237,8 @@ type EnvironmentVariables struct {
 	SessionSecret         string
 	SessionMaxAge         int
 	ProductionEnvironment bool
+	AdapterMode           string
+	SchedulerStore        string
 	AuthRedirectUrl       string
 	ClientDomain          string
 	ProjectName           string
@@ -88,7 +249,24 @@ type EnvironmentVariables struct {
 	OAuthProvider         *OAuthProvider
 	SMTP                  *SMTP
 	OpenAIApiKey          string
+	OpenAIModel           string
 	XDotCom               *XDotCom
+	Curation              *Curation
+	Mentions              *Mentions
+	Analytics             *Analytics
+	PostingTimes          *PostingTimes
+	Policy                *Policy
+	FactCheck             *FactCheck
+	Dedup                 *Dedup
+	Candidates            *Candidates
+	TopicBacklog          *TopicBacklog
+	ContentMix            *ContentMix
+	VectorSearch          *VectorSearch
+	Embeddings            *Embeddings
+	EmbeddingCache        *EmbeddingCache
+	Health                *Health
+	Timezone              string
+	PromptsDir            string
 }
 
 func loadEnv() {
@@ -102,8 +280,13 @@ func loadEnv() {
 
 func LoadEnvironment() *EnvironmentVariables {
 	loadEnv()
+	adapterMode := getEnv("ADAPTER_MODE", AdapterModeLive)
+	policy := DefaultPolicy()
+	embeddings := loadEmbeddings()
 	return &EnvironmentVariables{
 		Port:                  getEnv("PORT", ":5000"),
+		ShutdownTimeout:       time.Second * time.Duration(getEnvAsInt("SHUTDOWN_TIMEOUT_SECONDS", 30)),
+		SchedulerDrainTimeout: time.Second * time.Duration(getEnvAsInt("SCHEDULER_DRAIN_SECONDS", 120)),
 		JWTSecret:             getEnvOrError("JWT_SECRET"),
 		JWTMaxAge:             time.Second * time.Duration(getEnvAsInt("JWT_MAX_AGE", 60*15)),
 		RefreshJWTSecret:      getEnvOrError("REFRESH_JWT_SECRET"),
@@ -112,14 +295,16 @@ func LoadEnvironment() *EnvironmentVariables {
 		SessionSecret:         getEnvOrError("SESSIONS_SECRET"),
 		SessionMaxAge:         getEnvAsInt("SESSION_MAX_AGE", 86400*300),
 		ProductionEnvironment: getEnvAsBool("PRODUCTION_ENVIRONMENT", false),
+		AdapterMode:           adapterMode,
+		SchedulerStore:        getEnv("SCHEDULER_STORE", SchedulerStoreRedis),
 		ClientDomain:          getEnv("CLIENT_DOMAIN", "localhost"),
 		ProjectName:           getEnv("PROJECT_NAME", "rider"),
 		PostgresDB: &PostgresDB{
 			Username: getEnv("PG_DB_USERNAME", "postgres"),
-			Password: getEnvOrError("PG_DB_PASSWORD"),
+			Password: getLiveEnv("PG_DB_PASSWORD", adapterMode),
 			Host:     getEnv("PG_DB_HOST", "127.0.0.1"),
 			Port:     getEnvAsInt("PG_DB_PORT", 5432),
-			Name:     getEnvOrError("PG_DB_NAME"),
+			Name:     getLiveEnv("PG_DB_NAME", adapterMode),
 			SSLMode:  getEnv("PG_SSL_MODE", "disable"),
 		},
 		RedisCache: &RedisCache{
@@ -143,20 +328,166 @@ func LoadEnvironment() *EnvironmentVariables {
 			},
 		},
 		SMTP: &SMTP{
-			FromAddress: getEnvOrError("SMTP_FROM_ADDRESS"),
-			Host:        getEnvOrError("SMTP_HOST"),
+			FromAddress: getLiveEnv("SMTP_FROM_ADDRESS", adapterMode),
+			Host:        getLiveEnv("SMTP_HOST", adapterMode),
 			Port:        getEnvAsInt("SMTP_PORT", 587),
-			Username:    getEnvOrError("SMTP_USERNAME"),
-			Password:    getEnvOrError("SMTP_PASSWORD"),
+			Username:    getLiveEnv("SMTP_USERNAME", adapterMode),
+			Password:    getLiveEnv("SMTP_PASSWORD", adapterMode),
 		},
-		OpenAIApiKey: getEnvOrError("OPENAI_API_KEY"),
+		OpenAIApiKey: getOpenAIKey(embeddings.Provider, adapterMode),
+		OpenAIModel:  getEnv("OPENAI_MODEL", "gpt-4o"),
 		XDotCom: &XDotCom{
-			ConsumerKey:    getEnvOrError("CONSUMER_KEY"),
-			ConsumerSecret: getEnvOrError("CONSUMER_SECRET"),
-			AccessKey:      getEnvOrError("ACCESS_KEY"),
-			AccessSecret:   getEnvOrError("ACCESS_SECRET"),
-			BearerToken:    getEnvOrError("BEARER_TOKEN"),
+			ConsumerKey:    getLiveEnv("CONSUMER_KEY", adapterMode),
+			ConsumerSecret: getLiveEnv("CONSUMER_SECRET", adapterMode),
+			AccessKey:      getLiveEnv("ACCESS_KEY", adapterMode),
+			AccessSecret:   getLiveEnv("ACCESS_SECRET", adapterMode),
+			BearerToken:    getLiveEnv("BEARER_TOKEN", adapterMode),
+		},
+		Curation: &Curation{
+			Accounts:         getEnvAsSlice("CURATION_ACCOUNTS", []string{"Polkadot", "web3foundation"}),
+			QuoteThreshold:   getEnvAsFloat("CURATION_QUOTE_THRESHOLD", 0.8),
+			RetweetThreshold: getEnvAsFloat("CURATION_RETWEET_THRESHOLD", 0.65),
+			Interval:         time.Minute * time.Duration(getEnvAsInt("CURATION_INTERVAL_MINUTES", 30)),
+			MaxPerRun:        getEnvAsInt("CURATION_MAX_PER_RUN", 2),
 		},
+		Mentions: &Mentions{
+			Interval:            time.Minute * time.Duration(getEnvAsInt("MENTIONS_INTERVAL_MINUTES", 5)),
+			AutoReplyConfidence: getEnvAsFloat("MENTIONS_AUTO_REPLY_CONFIDENCE", 0.85),
+			DailyReplyLimit:     getEnvAsInt("MENTIONS_DAILY_REPLY_LIMIT", 30),
+			UserCooldown:        time.Minute * time.Duration(getEnvAsInt("MENTIONS_USER_COOLDOWN_MINUTES", 6*60)),
+		},
+		Analytics: &Analytics{
+			Interval:     time.Minute * time.Duration(getEnvAsInt("METRICS_INTERVAL_MINUTES", 60)),
+			LookbackDays: getEnvAsInt("METRICS_LOOKBACK_DAYS", 7),
+		},
+		PostingTimes: &PostingTimes{
+			LookbackDays:       getEnvAsInt("POSTING_TIMES_LOOKBACK_DAYS", 28),
+			Exploration:        getEnvAsFloat("POSTING_TIMES_EXPLORATION", 0.2),
+			ExplorationBonus:   getEnvAsFloat("POSTING_TIMES_EXPLORATION_BONUS", 1),
+			MinTweetsPerWindow: getEnvAsInt("POSTING_TIMES_MIN_PER_WINDOW", 1),
+			MaxTweetsPerWindow: getEnvAsInt("POSTING_TIMES_MAX_PER_WINDOW", 6),
+		},
+		Policy: &Policy{
+			// Patterns are separated by semicolons since commas are common in regular expressions
+			BlockedPatterns: getEnvAsSeparatedSlice("POLICY_BLOCKED_PATTERNS", ";", policy.BlockedPatterns),
+			BlockedKeywords: getEnvAsSlice("POLICY_BLOCKED_KEYWORDS", policy.BlockedKeywords),
+			AllowedDomains:  getEnvAsSlice("POLICY_ALLOWED_DOMAINS", policy.AllowedDomains),
+			AllowedTickers:  getEnvAsSlice("POLICY_ALLOWED_TICKERS", policy.AllowedTickers),
+			MaxHashtags:     getEnvAsInt("POLICY_MAX_HASHTAGS", policy.MaxHashtags),
+			MaxMentions:     getEnvAsInt("POLICY_MAX_MENTIONS", policy.MaxMentions),
+			LLMJudge:        getEnvAsBool("POLICY_LLM_JUDGE", policy.LLMJudge),
+		},
+		FactCheck: &FactCheck{
+			Enabled:     getEnvAsBool("FACT_CHECK_ENABLED", true),
+			MaxAttempts: getEnvAsInt("FACT_CHECK_MAX_ATTEMPTS", 2),
+			Passages:    getEnvAsInt("FACT_CHECK_PASSAGES", 3),
+		},
+		Dedup: &Dedup{
+			Enabled:          getEnvAsBool("DEDUP_ENABLED", true),
+			MaxAttempts:      getEnvAsInt("DEDUP_MAX_ATTEMPTS", 3),
+			RecentPosts:      getEnvAsInt("DEDUP_RECENT_POSTS", 200),
+			ShingleSize:      getEnvAsInt("DEDUP_SHINGLE_SIZE", 3),
+			LexicalThreshold: getEnvAsFloat("DEDUP_LEXICAL_THRESHOLD", 0.5),
+		},
+		Candidates: &Candidates{
+			Count:       getEnvAsInt("CANDIDATES_COUNT", 3),
+			JudgeModel:  getEnv("CANDIDATES_JUDGE_MODEL", "gpt-4o-mini"),
+			JudgeWeight: getEnvAsFloat("CANDIDATES_JUDGE_WEIGHT", 0.6),
+			Rubric: getEnv("CANDIDATES_RUBRIC", "- Hook: the first line makes a Polkadot follower stop scrolling\n"+
+				"- Accuracy: every claim is specific and grounded in the topic and context\n"+
+				"- Value: the reader learns something or has a reason to reply\n"+
+				"- Voice: it reads like a person from the community, not a press release\n"+
+				"- Clarity: plain words, one idea per sentence, no filler"),
+		},
+		TopicBacklog: &TopicBacklog{
+			MinQueued: getEnvAsInt("TOPIC_BACKLOG_MIN_QUEUED", 5),
+			Order:     getEnv("TOPIC_BACKLOG_ORDER", "priority"),
+		},
+		ContentMix: &ContentMix{
+			TopicTypes:       getEnvAsWeights("CONTENT_MIX_TOPIC_TYPES", map[string]float64{"product": 1, "standard": 2}),
+			Categories:       getEnvAsWeights("CONTENT_MIX_CATEGORIES", nil),
+			Formats:          getEnvAsWeights("CONTENT_MIX_FORMATS", map[string]float64{"short": 1, "thread": 1, "poll": 1}),
+			MaxPerDay:        getEnvAsCounts("CONTENT_MIX_MAX_PER_DAY", map[string]int{"thread": 4}),
+			NoRepeatCategory: getEnvAsBool("CONTENT_MIX_NO_REPEAT_CATEGORY", true),
+			Seed:             int64(getEnvAsInt("CONTENT_MIX_SEED", 0)),
+		},
+		VectorSearch: loadVectorSearch(),
+		Embeddings:   embeddings,
+		EmbeddingCache: &EmbeddingCache{
+			Enabled: getEnvAsBool("EMBEDDING_CACHE_ENABLED", true),
+			TTL:     time.Hour * time.Duration(getEnvAsInt("EMBEDDING_CACHE_TTL_HOURS", 720)),
+			Persist: getEnvAsBool("EMBEDDING_CACHE_PERSIST", false),
+		},
+		Health: &Health{
+			CheckTimeout:    time.Second * time.Duration(getEnvAsInt("HEALTH_CHECK_TIMEOUT_SECONDS", 5)),
+			CacheTTL:        time.Second * time.Duration(getEnvAsInt("HEALTH_CACHE_TTL_SECONDS", 30)),
+			HeartbeatMaxAge: time.Second * time.Duration(getEnvAsInt("HEALTH_HEARTBEAT_MAX_AGE_SECONDS", 180)),
+		},
+		Timezone:   getEnv("SCHEDULE_TIMEZONE", "America/New_York"),
+		PromptsDir: getEnv("PROMPTS_DIR", ""),
+	}
+}
+
+// loadEmbeddings names the hashing provider's own model unless another is
+// set, so its vectors are never compared with OpenAI's
+func loadEmbeddings() *Embeddings {
+	provider := getEnv("EMBEDDING_PROVIDER", EmbeddingProviderOpenAI)
+	model := "text-embedding-3-large"
+	if provider == EmbeddingProviderHashing {
+		model = "feature-hashing"
+	}
+	return &Embeddings{
+		Provider:       provider,
+		Model:          getEnv("OPENAI_EMBEDDING_MODEL", model),
+		Dimensions:     getEnvAsInt("OPENAI_EMBEDDING_DIMENSIONS", 0),
+		MigrationBatch: getEnvAsInt("EMBEDDING_MIGRATION_BATCH", 100),
+	}
+}
+
+// loadVectorSearch defaults the thresholds to the metric's score for the
+// same closeness: cosine similarities of 0.7, and 0.9 for tweets. Embeddings
+// are unit length, where the inner product is the cosine similarity and the
+// L2 distance is sqrt(2 - 2 * similarity), so switching the metric doesn't
+// change what counts as a duplicate
+func loadVectorSearch() *VectorSearch {
+	metric := getEnv("VECTOR_SEARCH_METRIC", "cosine")
+	threshold, thresholds := 0.7, map[string]float64{"topic": 0.7, "tweet": 0.9}
+	if metric == "l2" {
+		threshold = math.Sqrt(2 - 2*threshold)
+		for contentType, similarity := range thresholds {
+			thresholds[contentType] = math.Sqrt(2 - 2*similarity)
+		}
+	}
+
+	return &VectorSearch{
+		Metric:     metric,
+		Threshold:  getEnvAsFloat("VECTOR_SEARCH_THRESHOLD", threshold),
+		Thresholds: getEnvAsWeights("VECTOR_SEARCH_THRESHOLDS", thresholds),
+		Lookback:   24 * time.Hour * time.Duration(getEnvAsInt("VECTOR_SEARCH_LOOKBACK_DAYS", 0)),
+	}
+}
+
+// DefaultPolicy is the content policy drafts are reviewed against unless
+// the POLICY_* variables say otherwise
+func DefaultPolicy() *Policy {
+	return &Policy{
+		BlockedPatterns: []string{
+			`(?i)\bprice\s+(target|prediction)s?\b`,
+			`(?i)\b(price|dot|ksm)\b[^.!?]{0,40}\b(will|going to|gonna)\s+(hit|reach|pump|moon|flip|double|triple|\d+x)\b`,
+			`(?i)\$\d[\d,.]*\s*[km]?\s+(soon|by|next|eoy|eom|incoming)\b`,
+			`(?i)\b\d+x\s+(gains?|returns?|soon|incoming)\b`,
+			`(?i)\bto the moon\b`,
+		},
+		BlockedKeywords: []string{
+			"financial advice", "nfa", "dyor", "guaranteed returns", "risk-free", "buy now", "buy the dip",
+			"eth killer", "ghost chain", "dead chain", "scam chain",
+		},
+		AllowedDomains: []string{
+			"polkadot.com", "polkadot.network", "web3.foundation", "kusama.network", "substrate.io", "github.com", "x.com", "twitter.com",
+		},
+		AllowedTickers: []string{"DOT", "KSM"},
+		MaxHashtags:    2,
+		MaxMentions:    2,
 	}
 }
 
@@ -168,6 +499,25 @@ func getEnvOrError(key string) string {
 	panic("Environment variable " + key + " not set")
 }
 
+// getOpenAIKey requires the OpenAI key unless values are embedded locally
+// or memory mode runs without any external service. Prompts, and OpenAI
+// embeddings, still need it and fail without it when they are made
+func getOpenAIKey(provider, adapterMode string) string {
+	if provider == EmbeddingProviderHashing || adapterMode == AdapterModeMemory {
+		return getEnv("OPENAI_API_KEY", "")
+	}
+	return getEnvOrError("OPENAI_API_KEY")
+}
+
+// getLiveEnv requires what only the live adapters connect with, which
+// memory mode runs without
+func getLiveEnv(key string, adapterMode string) string {
+	if adapterMode == AdapterModeMemory {
+		return getEnv(key, "")
+	}
+	return getEnvOrError(key)
+}
+
 func getEnv(key string, fallback string) string {
 	value, exists := os.LookupEnv(key)
 	if exists {
@@ -199,3 +549,68 @@ func getEnvAsBool(key string, fallback bool) bool {
 	}
 	return fallback
 }
+
+func getEnvAsFloat(key string, fallback float64) float64 {
+	value, exist := os.LookupEnv(key)
+	if exist {
+		valueFloat, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			log.Panicf("Environment variable \"%v\" not set properly", key)
+		}
+		return valueFloat
+	}
+	return fallback
+}
+
+func getEnvAsSlice(key string, fallback []string) []string {
+	return getEnvAsSeparatedSlice(key, ",", fallback)
+}
+
+func getEnvAsSeparatedSlice(key, separator string, fallback []string) []string {
+	value, exist := os.LookupEnv(key)
+	if exist {
+		var values []string
+		for _, item := range strings.Split(value, separator) {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+	return fallback
+}
+
+// getEnvAsWeights reads comma-separated "name:weight" pairs
+func getEnvAsWeights(key string, fallback map[string]float64) map[string]float64 {
+	if _, exist := os.LookupEnv(key); !exist {
+		return fallback
+	}
+	weights := map[string]float64{}
+	for _, pair := range getEnvAsSlice(key, nil) {
+		name, weight, found := strings.Cut(pair, ":")
+		if !found {
+			log.Panicf("Environment variable \"%v\" not set properly", key)
+		}
+		valueFloat, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil || valueFloat < 0 {
+			log.Panicf("Environment variable \"%v\" not set properly", key)
+		}
+		weights[strings.TrimSpace(name)] = valueFloat
+	}
+	return weights
+}
+
+// getEnvAsCounts reads comma-separated "name:count" pairs
+func getEnvAsCounts(key string, fallback map[string]int) map[string]int {
+	if _, exist := os.LookupEnv(key); !exist {
+		return fallback
+	}
+	counts := map[string]int{}
+	for name, weight := range getEnvAsWeights(key, nil) {
+		if weight != float64(int(weight)) {
+			log.Panicf("Environment variable \"%v\" not set properly", key)
+		}
+		counts[name] = int(weight)
+	}
+	return counts
+}
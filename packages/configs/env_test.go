@@ -0,0 +1,31 @@
+package configs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadVectorSearch(t *testing.T) {
+	search := loadVectorSearch()
+	assert.Equal(t, 0.9, search.Thresholds["tweet"])
+
+	// Unit vectors 0.9 similar are sqrt(0.2) apart
+	t.Setenv("VECTOR_SEARCH_METRIC", "l2")
+	search = loadVectorSearch()
+	assert.InDelta(t, 0.4472, search.Thresholds["tweet"], 0.0001)
+	assert.InDelta(t, 0.7746, search.Threshold, 0.0001)
+
+	t.Setenv("VECTOR_SEARCH_THRESHOLDS", "tweet:0.3")
+	assert.Equal(t, map[string]float64{"tweet": 0.3}, loadVectorSearch().Thresholds)
+}
+
+func TestGetOpenAIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	_ = os.Unsetenv("OPENAI_API_KEY")
+
+	assert.Empty(t, getOpenAIKey(EmbeddingProviderHashing, AdapterModeLive))
+	assert.Empty(t, getOpenAIKey(EmbeddingProviderOpenAI, AdapterModeMemory))
+	assert.Panics(t, func() { getOpenAIKey(EmbeddingProviderOpenAI, AdapterModeLive) })
+}
@@ -0,0 +1,158 @@
+package imagecard
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	Width  = 1200
+	Height = 675
+
+	padding          = 80
+	accentWidth      = 24
+	maxHeadlineSize  = 84
+	minHeadlineSize  = 36
+	captionSize      = 32
+	brandSize        = 26
+	sectionSpacing   = 28
+	headlineSizeStep = 4
+)
+
+var (
+	backgroundColor = color.RGBA{R: 0x12, G: 0x12, B: 0x1c, A: 0xff}
+	accentColor     = color.RGBA{R: 0xe6, G: 0x00, B: 0x7a, A: 0xff}
+	headlineColor   = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	captionColor    = color.RGBA{R: 0xc8, G: 0xc8, B: 0xd4, A: 0xff}
+)
+
+// The fonts are parsed once; faces of any size are made from them per render
+var bold, regular = mustParse(gobold.TTF), mustParse(goregular.TTF)
+
+func mustParse(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Card is the content of a branded image card, usually a key stat or quote pulled from a thread
+type Card struct {
+	Headline string
+	Caption  string
+	Brand    string
+}
+
+// Render draws the card onto a 1200x675 canvas, the aspect ratio X uses for in-feed images, and returns it as a PNG
+func Render(card Card) ([]byte, error) {
+	if strings.TrimSpace(card.Headline) == "" {
+		return nil, errors.New("card headline is empty")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, accentWidth, Height), &image.Uniform{C: accentColor}, image.Point{}, draw.Src)
+
+	textWidth := Width - 2*padding
+	bottom := Height - padding
+
+	// Brand footer is anchored to the bottom of the card
+	if card.Brand != "" {
+		brandFace, err := newFace(regular, brandSize)
+		if err != nil {
+			return nil, err
+		}
+		lineHeight := brandFace.Metrics().Height.Ceil()
+		drawLines(img, brandFace, accentColor, []string{card.Brand}, padding, bottom-lineHeight)
+		bottom -= lineHeight + sectionSpacing
+	}
+
+	// Caption sits directly above the footer
+	if card.Caption != "" {
+		captionFace, err := newFace(regular, captionSize)
+		if err != nil {
+			return nil, err
+		}
+		lines := wrap(captionFace, card.Caption, textWidth)
+		height := len(lines) * captionFace.Metrics().Height.Ceil()
+		drawLines(img, captionFace, captionColor, lines, padding, bottom-height)
+		bottom -= height + sectionSpacing
+	}
+
+	// Headline takes the largest font size that still fits the remaining space
+	available := bottom - padding
+	for size := maxHeadlineSize; size >= minHeadlineSize; size -= headlineSizeStep {
+		headlineFace, err := newFace(bold, float64(size))
+		if err != nil {
+			return nil, err
+		}
+		lines := wrap(headlineFace, card.Headline, textWidth)
+		height := len(lines) * headlineFace.Metrics().Height.Ceil()
+		if height <= available || size-headlineSizeStep < minHeadlineSize {
+			drawLines(img, headlineFace, headlineColor, lines, padding, padding+(available-height)/2)
+			break
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// wrap breaks text into lines no wider than maxWidth. A single word wider than maxWidth gets a line of its own.
+func wrap(face font.Face, text string, maxWidth int) []string {
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if current != "" && font.MeasureString(face, candidate).Ceil() > maxWidth {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current = candidate
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func drawLines(img draw.Image, face font.Face, textColor color.Color, lines []string, x, top int) {
+	metrics := face.Metrics()
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+	}
+	for i, line := range lines {
+		baseline := top + i*metrics.Height.Ceil() + metrics.Ascent.Ceil()
+		drawer.Dot = fixed.P(x, baseline)
+		drawer.DrawString(line)
+	}
+}
@@ -0,0 +1,68 @@
+package imagecard
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    Card
+		wantErr bool
+	}{
+		{
+			name: "headline only",
+			card: Card{Headline: "Polkadot processed 1M XCM messages last month"},
+		},
+		{
+			name: "full card",
+			card: Card{
+				Headline: strings.Repeat("Shared security for every parachain ", 8),
+				Caption:  "Why Polkadot's relay chain matters",
+				Brand:    "Polkadot AI Yapper",
+			},
+		},
+		{
+			name:    "empty headline",
+			card:    Card{Headline: "   "},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.card)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(got))
+			assert.NoError(t, err)
+			assert.Equal(t, Width, img.Bounds().Dx())
+			assert.Equal(t, Height, img.Bounds().Dy())
+		})
+	}
+}
+
+func Test_wrap(t *testing.T) {
+	regular, err := opentype.Parse(goregular.TTF)
+	assert.NoError(t, err)
+	face, err := newFace(regular, 32)
+	assert.NoError(t, err)
+
+	maxWidth := 400
+	lines := wrap(face, "Nominated proof-of-stake lets DOT holders back validators they trust with their stake", maxWidth)
+	assert.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, font.MeasureString(face, line).Ceil(), maxWidth, line)
+	}
+}
@@ -39,7 +39,12 @@ func NewRedisClient(env *configs.EnvironmentVariables) *redis.Client {
 	return redisClient
 }
 
+// NewOpenAIClient returns nil without a key, which memory mode and local
+// embeddings run without. Calls to OpenAI then fail when they are made
 func NewOpenAIClient(env *configs.EnvironmentVariables) *openai.Client {
+	if env.OpenAIApiKey == "" {
+		return nil
+	}
 	return openai.NewClient(
 		option.WithAPIKey(env.OpenAIApiKey),
 	)
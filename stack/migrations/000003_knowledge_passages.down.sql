@@ -0,0 +1 @@
+DROP TABLE IF EXISTS knowledge_passages;
@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- Reference material used to ground generated content and score curated posts
+CREATE TABLE IF NOT EXISTS knowledge_passages
+(
+    id         SERIAL PRIMARY KEY,
+    source     TEXT      NOT NULL DEFAULT '',
+    content    TEXT      NOT NULL,
+    embedding  vector(3072),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
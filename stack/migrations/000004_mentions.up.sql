@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS mentions
+(
+    id              SERIAL PRIMARY KEY,
+    tweet_id        VARCHAR(64)      NOT NULL UNIQUE,
+    author_id       VARCHAR(64)      NOT NULL,
+    author_username VARCHAR(256)     NOT NULL DEFAULT '',
+    text            TEXT             NOT NULL,
+    classification  VARCHAR(32)      NOT NULL DEFAULT '',
+    confidence      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    draft           TEXT             NOT NULL DEFAULT '',
+    status          VARCHAR(32)      NOT NULL DEFAULT 'pending',
+    reply_tweet_id  VARCHAR(64)      NOT NULL DEFAULT '',
+    created_at      TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS mentions_status_idx ON mentions (status);
@@ -0,0 +1,31 @@
+-- Everything the bot publishes, with the choices that produced it
+CREATE TABLE IF NOT EXISTS posts
+(
+    id              SERIAL PRIMARY KEY,
+    topic           TEXT        NOT NULL,
+    topic_type      VARCHAR(32) NOT NULL DEFAULT '',
+    category        VARCHAR(64) NOT NULL DEFAULT '',
+    format          VARCHAR(32) NOT NULL DEFAULT '',
+    prompt_template VARCHAR(64) NOT NULL DEFAULT '',
+    tweet_ids       TEXT[]      NOT NULL DEFAULT '{}',
+    posted_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS posts_posted_at_idx ON posts (posted_at);
+
+-- Time series of public metrics for every tweet of a post
+CREATE TABLE IF NOT EXISTS tweet_metrics
+(
+    id           SERIAL PRIMARY KEY,
+    post_id      INTEGER     NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    tweet_id     VARCHAR(64) NOT NULL,
+    impressions  INTEGER     NOT NULL DEFAULT 0,
+    likes        INTEGER     NOT NULL DEFAULT 0,
+    reposts      INTEGER     NOT NULL DEFAULT 0,
+    replies      INTEGER     NOT NULL DEFAULT 0,
+    quotes       INTEGER     NOT NULL DEFAULT 0,
+    bookmarks    INTEGER     NOT NULL DEFAULT 0,
+    collected_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS tweet_metrics_tweet_id_collected_at_idx ON tweet_metrics (tweet_id, collected_at DESC);
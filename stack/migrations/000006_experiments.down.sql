@@ -0,0 +1,6 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS variant_id,
+    DROP COLUMN IF EXISTS experiment_id;
+
+DROP TABLE IF EXISTS experiment_variants;
+DROP TABLE IF EXISTS experiments;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS experiments
+(
+    id                SERIAL PRIMARY KEY,
+    name              VARCHAR(128) NOT NULL,
+    template          VARCHAR(64)  NOT NULL,
+    status            VARCHAR(32)  NOT NULL DEFAULT 'running',
+    winner_variant_id INTEGER,
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    stopped_at        TIMESTAMPTZ
+);
+
+-- Only one experiment can drive a template at a time
+CREATE UNIQUE INDEX IF NOT EXISTS experiments_active_template_idx ON experiments (template) WHERE status IN ('running', 'promoted');
+
+CREATE TABLE IF NOT EXISTS experiment_variants
+(
+    id            SERIAL PRIMARY KEY,
+    experiment_id INTEGER     NOT NULL REFERENCES experiments (id) ON DELETE CASCADE,
+    name          VARCHAR(64) NOT NULL,
+    body          TEXT        NOT NULL,
+    weight        INTEGER     NOT NULL CHECK (weight > 0),
+    UNIQUE (experiment_id, name)
+);
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS experiment_id INTEGER REFERENCES experiments (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS variant_id    INTEGER REFERENCES experiment_variants (id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS prompt_version;
+
+DROP TABLE IF EXISTS prompt_templates;
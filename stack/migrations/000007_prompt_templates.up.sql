@@ -0,0 +1,14 @@
+-- Template versions created through the admin API; they take precedence over the template files
+CREATE TABLE IF NOT EXISTS prompt_templates
+(
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(64) NOT NULL,
+    version    INTEGER     NOT NULL,
+    body       TEXT        NOT NULL,
+    active     BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (name, version)
+);
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS prompt_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS persona_id;
+
+DROP TABLE IF EXISTS personas;
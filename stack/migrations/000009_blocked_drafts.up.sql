@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS blocked_drafts
+(
+    id         SERIAL PRIMARY KEY,
+    topic      TEXT        NOT NULL,
+    draft      JSONB       NOT NULL,
+    violations JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS blocked_drafts_created_at_idx ON blocked_drafts (created_at DESC);
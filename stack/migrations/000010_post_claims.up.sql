@@ -0,0 +1,3 @@
+-- The fact-check report of each post: its claims and what the knowledge base says about them
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS claims JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS candidate_batch;
+
+DROP TABLE IF EXISTS candidates;
@@ -0,0 +1,22 @@
+-- Every draft generated for a slot and how it scored, so the selection can be analysed later
+CREATE TABLE IF NOT EXISTS candidates
+(
+    id              SERIAL PRIMARY KEY,
+    batch           UUID             NOT NULL,
+    topic           TEXT             NOT NULL,
+    format          VARCHAR(32)      NOT NULL DEFAULT '',
+    prompt_template VARCHAR(64)      NOT NULL DEFAULT '',
+    texts           TEXT[]           NOT NULL DEFAULT '{}',
+    heuristics      JSONB            NOT NULL DEFAULT '{}',
+    heuristic       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    judge           DOUBLE PRECISION,
+    judge_reason    TEXT             NOT NULL DEFAULT '',
+    score           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    selected        BOOLEAN          NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMPTZ      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS candidates_batch_idx ON candidates (batch);
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS candidate_batch UUID;
@@ -0,0 +1,16 @@
+-- Generated and hand-picked topics waiting to be tweeted. Used topics are kept
+-- with the time they were taken off the backlog
+CREATE TABLE IF NOT EXISTS topics
+(
+    id           SERIAL PRIMARY KEY,
+    text         TEXT         NOT NULL,
+    topic_type   VARCHAR(32)  NOT NULL,
+    category     VARCHAR(128) NOT NULL DEFAULT '',
+    source       VARCHAR(64)  NOT NULL DEFAULT '',
+    priority     INTEGER      NOT NULL DEFAULT 0,
+    embedding_id INTEGER REFERENCES embeddings (id) ON DELETE SET NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS topics_queued_idx ON topics (topic_type, priority DESC, created_at) WHERE used_at IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE topics
+    DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS projects;
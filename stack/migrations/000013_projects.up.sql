@@ -0,0 +1,18 @@
+-- Curated Polkadot ecosystem projects product topics are written about
+CREATE TABLE IF NOT EXISTS projects
+(
+    id               SERIAL PRIMARY KEY,
+    name             VARCHAR(128) NOT NULL UNIQUE,
+    category         VARCHAR(64)  NOT NULL DEFAULT '',
+    website          VARCHAR(256) NOT NULL DEFAULT '',
+    x_handle         VARCHAR(64)  NOT NULL DEFAULT '',
+    status           VARCHAR(16)  NOT NULL DEFAULT 'live',
+    description      TEXT         NOT NULL DEFAULT '',
+    last_featured_at TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Product topics remember the project they were generated for
+ALTER TABLE topics
+    ADD COLUMN IF NOT EXISTS project_id INTEGER REFERENCES projects (id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS embeddings_content_type_created_at_idx;
+DROP INDEX IF EXISTS embeddings_embedding_cosine_idx;
+
+ALTER TABLE embeddings
+    ALTER COLUMN embedding TYPE vector(3072) USING embedding::vector(3072);
+
+ALTER TABLE embeddings
+    DROP COLUMN IF EXISTS content_type;
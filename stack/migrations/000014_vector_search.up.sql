@@ -0,0 +1,14 @@
+-- Embeddings are compared per content type, each with its own threshold
+ALTER TABLE embeddings
+    ADD COLUMN IF NOT EXISTS content_type VARCHAR(32) NOT NULL DEFAULT 'topic';
+
+-- HNSW indexes vector columns of up to 2000 dimensions but halfvec columns of
+-- up to 4000, so half precision is what lets 3072 dimensions be indexed.
+-- Needs pgvector 0.7 or later
+ALTER TABLE embeddings
+    ALTER COLUMN embedding TYPE halfvec(3072) USING embedding::halfvec(3072);
+
+-- The index serves the cosine metric. Searching with VECTOR_SEARCH_METRIC=l2
+-- or inner_product needs an index with halfvec_l2_ops or halfvec_ip_ops instead
+CREATE INDEX IF NOT EXISTS embeddings_embedding_cosine_idx ON embeddings USING hnsw (embedding halfvec_cosine_ops);
+CREATE INDEX IF NOT EXISTS embeddings_content_type_created_at_idx ON embeddings (content_type, created_at);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS embeddings_next;
+DROP TABLE IF EXISTS embedding_migrations;
+
+DROP INDEX IF EXISTS embeddings_model_content_type_created_at_idx;
+CREATE INDEX IF NOT EXISTS embeddings_content_type_created_at_idx ON embeddings (content_type, created_at);
+
+ALTER TABLE embeddings
+    DROP COLUMN IF EXISTS dimensions,
+    DROP COLUMN IF EXISTS model;
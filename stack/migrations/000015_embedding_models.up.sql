@@ -0,0 +1,30 @@
+-- Every embedding records the model and dimension it was made with, so
+-- vectors from different models are never compared
+ALTER TABLE embeddings
+    ADD COLUMN IF NOT EXISTS model      VARCHAR(64) NOT NULL DEFAULT 'text-embedding-3-large',
+    ADD COLUMN IF NOT EXISTS dimensions INTEGER     NOT NULL DEFAULT 3072;
+
+DROP INDEX IF EXISTS embeddings_content_type_created_at_idx;
+CREATE INDEX IF NOT EXISTS embeddings_model_content_type_created_at_idx ON embeddings (model, content_type, created_at);
+
+-- A migration re-embeds every value with another model before cutting over to it
+CREATE TABLE IF NOT EXISTS embedding_migrations
+(
+    id           SERIAL PRIMARY KEY,
+    model        VARCHAR(64) NOT NULL,
+    dimensions   INTEGER     NOT NULL,
+    status       VARCHAR(16) NOT NULL DEFAULT 'running',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ
+);
+
+-- Only one migration runs at a time
+CREATE UNIQUE INDEX IF NOT EXISTS embedding_migrations_running_idx ON embedding_migrations ((status)) WHERE status = 'running';
+
+-- Shadow embeddings made by the running migration, swapped in at cut-over
+CREATE TABLE IF NOT EXISTS embeddings_next
+(
+    embedding_id INTEGER PRIMARY KEY REFERENCES embeddings (id) ON DELETE CASCADE,
+    migration_id INTEGER NOT NULL REFERENCES embedding_migrations (id) ON DELETE CASCADE,
+    embedding    halfvec NOT NULL
+);
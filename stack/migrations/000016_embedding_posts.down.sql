@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS posts_topic_idx;
+
+ALTER TABLE embeddings
+    DROP COLUMN IF EXISTS post_id;
@@ -0,0 +1,6 @@
+-- Published tweets are embedded with the post they went out in, so a
+-- search can link back to them
+ALTER TABLE embeddings
+    ADD COLUMN IF NOT EXISTS post_id INTEGER REFERENCES posts (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS posts_topic_idx ON posts (topic);
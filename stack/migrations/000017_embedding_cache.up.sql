@@ -0,0 +1,9 @@
+-- Embeddings by the model and text they were made from, so the same text
+-- is never sent to the provider twice
+CREATE TABLE IF NOT EXISTS embedding_cache
+(
+    key        TEXT PRIMARY KEY,
+    model      VARCHAR(64) NOT NULL,
+    embedding  REAL[]      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
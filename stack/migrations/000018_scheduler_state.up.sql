@@ -0,0 +1,8 @@
+-- The scheduler's quotas, schedule, stats, locks, cursors and cooldowns,
+-- for deployments that run without Redis. Rows past expires_at are ignored
+CREATE TABLE IF NOT EXISTS scheduler_state
+(
+    key        TEXT PRIMARY KEY,
+    value      TEXT NOT NULL,
+    expires_at TIMESTAMPTZ
+);
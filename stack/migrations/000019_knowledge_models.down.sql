@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS knowledge_passages_next;
+
+-- Passages of another dimension can't be kept in the original column
+DELETE FROM knowledge_passages WHERE dimensions <> 3072;
+ALTER TABLE knowledge_passages ALTER COLUMN embedding TYPE vector(3072);
+
+ALTER TABLE knowledge_passages
+    DROP COLUMN IF EXISTS dimensions,
+    DROP COLUMN IF EXISTS model;
@@ -0,0 +1,16 @@
+-- Knowledge passages record the model and dimension they were embedded
+-- with, so embedding migrations re-embed them along with every other value
+ALTER TABLE knowledge_passages
+    ADD COLUMN IF NOT EXISTS model      VARCHAR(64) NOT NULL DEFAULT 'text-embedding-3-large',
+    ADD COLUMN IF NOT EXISTS dimensions INTEGER     NOT NULL DEFAULT 3072;
+
+-- A migration may change the dimension, so the column takes any
+ALTER TABLE knowledge_passages ALTER COLUMN embedding TYPE vector;
+
+-- Shadow embeddings of passages made by the running migration, swapped in at cut-over
+CREATE TABLE IF NOT EXISTS knowledge_passages_next
+(
+    passage_id   INTEGER PRIMARY KEY REFERENCES knowledge_passages (id) ON DELETE CASCADE,
+    migration_id INTEGER NOT NULL REFERENCES embedding_migrations (id) ON DELETE CASCADE,
+    embedding    vector  NOT NULL
+);
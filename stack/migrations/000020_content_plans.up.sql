@@ -0,0 +1,8 @@
+-- Daily content plans, for deployments that keep the scheduler's state in
+-- Postgres. Plans are kept a week to compare against what went out
+CREATE TABLE IF NOT EXISTS content_plans
+(
+    date       DATE PRIMARY KEY,
+    plan       JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+UPDATE personas
+SET signature_phrases = ARRAY ['not financial advice', 'doing my own research', 'to the moon', 'diamond hands', 'bullish', 'based', 'probably nothing', 'few understand', 'wagmi', 'IYKYK', 'DYOR', 'LFG'],
+    updated_at        = CURRENT_TIMESTAMP
+WHERE name = 'degen';
@@ -0,0 +1,8 @@
+-- The content policy blocks these, so a draft that worked them in as
+-- asked was never published
+UPDATE personas
+SET signature_phrases = ARRAY(SELECT phrase
+                              FROM unnest(signature_phrases) AS phrase
+                              WHERE lower(phrase) NOT IN ('not financial advice', 'to the moon', 'dyor')),
+    updated_at        = CURRENT_TIMESTAMP
+WHERE name = 'degen';